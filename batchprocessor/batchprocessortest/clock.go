@@ -0,0 +1,90 @@
+package batchprocessortest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock 手动推进的时钟，Now 可直接传给 batchprocessor.WithDedupClock
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+	changed chan struct{} // waiters 数量变化时关闭并重建，供 BlockUntil 等待
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start, changed: make(chan struct{})}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After d<=0 时立即触发，否则等到 Advance 推进到期
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, &waiter{at: c.now.Add(d), ch: ch})
+	c.notify()
+	return ch
+}
+
+// Advance 推进时间，按到期先后唤醒 waiter
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+
+	sort.SliceStable(c.waiters, func(i, j int) bool { return c.waiters[i].at.Before(c.waiters[j].at) })
+	var fired int
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			break
+		}
+		w.ch <- c.now
+		fired++
+	}
+	if fired > 0 {
+		c.waiters = append(c.waiters[:0], c.waiters[fired:]...)
+		c.notify()
+	}
+}
+
+// Waiters 当前阻塞在 After 上的数量
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil 阻塞直到有 n 个 waiter，用于在 Advance 前确认被测代码已经开始等待
+func (c *FakeClock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		cnt, changed := len(c.waiters), c.changed
+		c.mu.Unlock()
+		if cnt >= n {
+			return
+		}
+		<-changed
+	}
+}
+
+func (c *FakeClock) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
@@ -0,0 +1,76 @@
+package batchprocessortest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/1298509345/go-utils-frequently/batchprocessor"
+)
+
+type (
+	// Page 脚本中的一页，Latency 基于 FakeClock 计时
+	Page[T any] struct {
+		Items   []T
+		Latency time.Duration
+		Err     error
+	}
+
+	FetchCall struct {
+		Page     int
+		PageSize int
+	}
+
+	// ScriptedFetcher 按脚本返回数据的 Fetcher，第 i 页对应 pages[i-1]，超出脚本返回空批次
+	ScriptedFetcher[T any] struct {
+		clock *FakeClock
+		pages []Page[T]
+
+		mu    sync.Mutex
+		calls []FetchCall
+	}
+)
+
+// NewScriptedFetcher clock 为 nil 时忽略 Latency
+func NewScriptedFetcher[T any](clock *FakeClock, pages ...Page[T]) *ScriptedFetcher[T] {
+	return &ScriptedFetcher[T]{clock: clock, pages: pages}
+}
+
+// Pages 把 items 按 pageSize 切成脚本页
+func Pages[T any](items []T, pageSize int) []Page[T] {
+	var pages []Page[T]
+	for start := 0; start < len(items); start += pageSize {
+		pages = append(pages, Page[T]{Items: items[start:min(start+pageSize, len(items))]})
+	}
+	return pages
+}
+
+func (f *ScriptedFetcher[T]) Fetcher() batchprocessor.Fetcher[T] {
+	return f.Fetch
+}
+
+func (f *ScriptedFetcher[T]) Fetch(ctx context.Context, page int, pageSize int) ([]T, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, FetchCall{Page: page, PageSize: pageSize})
+	f.mu.Unlock()
+
+	if page < 1 || page > len(f.pages) {
+		return nil, nil
+	}
+	p := f.pages[page-1]
+	if p.Latency > 0 && f.clock != nil {
+		select {
+		case <-f.clock.After(p.Latency):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return p.Items, p.Err
+}
+
+// Calls 按调用顺序返回所有拉取记录
+func (f *ScriptedFetcher[T]) Calls() []FetchCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FetchCall(nil), f.calls...)
+}
@@ -0,0 +1,142 @@
+package batchprocessortest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/1298509345/go-utils-frequently/batchprocessor"
+)
+
+type ctxKey struct{}
+
+func TestRecordingProcessor_Order(t *testing.T) {
+	var (
+		items   = []int{1, 2, 3, 4, 5, 6, 7}
+		fetcher = NewScriptedFetcher(nil, Pages(items, 3)...)
+		rec     = NewRecordingProcessor[int](nil, nil)
+		ctx     = context.WithValue(context.Background(), ctxKey{}, "v")
+	)
+	bp := batchprocessor.New(
+		batchprocessor.WithBatchSize[int](3),
+		batchprocessor.WithProcessor(rec.Processor()),
+	)
+	if err := bp.ProcessFetcher(ctx, fetcher.Fetcher(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := rec.Items(); !reflect.DeepEqual(got, items) {
+		t.Errorf("Items() = %v, want %v", got, items)
+	}
+	if rec.MaxOverlap() != 1 {
+		t.Errorf("MaxOverlap() = %v, want 1", rec.MaxOverlap())
+	}
+	for _, c := range rec.Calls() {
+		if c.Ctx.Value(ctxKey{}) != "v" {
+			t.Errorf("call %d lost context value", c.Seq)
+		}
+	}
+	wantCalls := []FetchCall{{1, 3}, {2, 3}, {3, 3}, {4, 3}}
+	if got := fetcher.Calls(); !reflect.DeepEqual(got, wantCalls) {
+		t.Errorf("fetch calls = %v, want %v", got, wantCalls)
+	}
+}
+
+func TestRecordingProcessor_Overlap(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		rec     *RecordingProcessor[int]
+	)
+	rec = NewRecordingProcessor(nil, func(context.Context, []int) error {
+		<-release
+		return nil
+	})
+	bp := batchprocessor.New(
+		batchprocessor.WithBatchSize[int](1),
+		batchprocessor.WithConcurrencyLimit[int](3),
+		batchprocessor.WithProcessor(rec.Processor()),
+	)
+
+	done := make(chan error)
+	go func() {
+		done <- bp.Process(context.Background(), []int{1, 2, 3, 4, 5})
+	}()
+	for rec.Running() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if rec.MaxOverlap() != 3 {
+		t.Errorf("MaxOverlap() = %v, want 3", rec.MaxOverlap())
+	}
+}
+
+func TestScriptedFetcher_LatencyAndError(t *testing.T) {
+	var (
+		clock   = NewFakeClock(time.Unix(0, 0))
+		errPage = errors.New("page 2 down")
+		fetcher = NewScriptedFetcher(clock,
+			Page[int]{Items: []int{1, 2}, Latency: time.Second},
+			Page[int]{Items: []int{3}, Err: errPage},
+		)
+		rec = NewRecordingProcessor[int](clock, nil)
+		bp  = batchprocessor.New(
+			batchprocessor.WithBatchSize[int](2),
+			batchprocessor.WithProcessor(rec.Processor()),
+		)
+	)
+
+	done := make(chan error)
+	go func() {
+		done <- bp.ProcessFetcher(context.Background(), fetcher.Fetcher(), 0)
+	}()
+
+	clock.BlockUntil(1)
+	if len(rec.Calls()) != 0 {
+		t.Fatal("processor called before latency elapsed")
+	}
+	clock.Advance(time.Second)
+
+	if err := <-done; !errors.Is(err, errPage) {
+		t.Fatalf("ProcessFetcher() error = %v, want %v", err, errPage)
+	}
+	calls := rec.Calls()
+	if len(calls) != 1 || !calls[0].Start.Equal(time.Unix(1, 0)) {
+		t.Errorf("calls = %+v", calls)
+	}
+}
+
+func TestScriptedFetcher_Cancel(t *testing.T) {
+	var (
+		clock       = NewFakeClock(time.Unix(0, 0))
+		fetcher     = NewScriptedFetcher(clock, Page[int]{Items: []int{1}, Latency: time.Hour})
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	cancel()
+	if _, err := fetcher.Fetch(ctx, 1, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("Fetch() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestFakeClock_DedupWindow(t *testing.T) {
+	var (
+		clock = NewFakeClock(time.Unix(0, 0))
+		d     = batchprocessor.NewDeduper(func(i int) int { return i },
+			batchprocessor.WithDedupWindow[int, int](0, time.Minute),
+			batchprocessor.WithDedupClock[int, int](clock.Now),
+		)
+	)
+	d.Dedup([]int{1, 2})
+	clock.Advance(30 * time.Second)
+	if got := d.Dedup([]int{1, 3}); !reflect.DeepEqual(got, []int{3}) {
+		t.Errorf("Dedup() = %v, want [3]", got)
+	}
+	clock.Advance(30 * time.Second)
+	if got := d.Dedup([]int{1, 3}); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Dedup() = %v, want [1]", got)
+	}
+}
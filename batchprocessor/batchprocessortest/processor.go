@@ -0,0 +1,111 @@
+package batchprocessortest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/1298509345/go-utils-frequently/batchprocessor"
+)
+
+type (
+	// Call 一次 Processor 调用的记录
+	Call[T any] struct {
+		Seq        int // 开始顺序，从0开始
+		Batch      []T // 调用时批次的副本
+		Ctx        context.Context
+		Start, End time.Time // 设置了 clock 时记录
+		Overlap    int       // 开始时正在运行的调用数（含自身）
+		Err        error
+	}
+
+	// RecordingProcessor 记录调用顺序、并发重叠和 context，可包装被测的 Processor
+	RecordingProcessor[T any] struct {
+		inner batchprocessor.Processor[T]
+		clock *FakeClock
+
+		mu         sync.Mutex
+		calls      []*Call[T]
+		running    int
+		maxRunning int
+	}
+)
+
+// NewRecordingProcessor inner 为 nil 时只记录不处理；clock 可为 nil
+func NewRecordingProcessor[T any](clock *FakeClock, inner batchprocessor.Processor[T]) *RecordingProcessor[T] {
+	return &RecordingProcessor[T]{inner: inner, clock: clock}
+}
+
+func (r *RecordingProcessor[T]) Processor() batchprocessor.Processor[T] {
+	return r.Process
+}
+
+func (r *RecordingProcessor[T]) Process(ctx context.Context, batch []T) error {
+	call := &Call[T]{
+		Batch: append([]T(nil), batch...),
+		Ctx:   ctx,
+		Start: r.now(),
+	}
+	r.mu.Lock()
+	call.Seq = len(r.calls)
+	r.running++
+	r.maxRunning = max(r.maxRunning, r.running)
+	call.Overlap = r.running
+	r.calls = append(r.calls, call)
+	r.mu.Unlock()
+
+	var err error
+	if r.inner != nil {
+		err = r.inner(ctx, batch)
+	}
+
+	r.mu.Lock()
+	r.running--
+	call.End = r.now()
+	call.Err = err
+	r.mu.Unlock()
+	return err
+}
+
+func (r *RecordingProcessor[T]) now() time.Time {
+	if r.clock == nil {
+		return time.Time{}
+	}
+	return r.clock.Now()
+}
+
+// Calls 按开始顺序返回调用记录的副本
+func (r *RecordingProcessor[T]) Calls() []Call[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := make([]Call[T], 0, len(r.calls))
+	for _, c := range r.calls {
+		ret = append(ret, *c)
+	}
+	return ret
+}
+
+// Items 按调用开始顺序拼接的所有元素
+func (r *RecordingProcessor[T]) Items() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ret []T
+	for _, c := range r.calls {
+		ret = append(ret, c.Batch...)
+	}
+	return ret
+}
+
+// MaxOverlap 观察到的最大并发调用数
+func (r *RecordingProcessor[T]) MaxOverlap() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.maxRunning
+}
+
+// Running 当前正在运行的调用数
+func (r *RecordingProcessor[T]) Running() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.running
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/1298509345/go-utils-frequently/optional"
+	"github.com/1298509345/go-utils-frequently/result"
 	"golang.org/x/sync/errgroup"
+	"sync/atomic"
 )
 
 const (
@@ -21,6 +23,9 @@ type (
 		ProcFunc         Processor[T]
 		BatchSize        int
 		ConcurrencyLimit int
+		Deduper          Deduplicator[T] // 可选，派发前对批次去重
+		MemoryBudget     int64           // 缓冲+处理中批次的字节上限，<=0 不限制
+		SizeFunc         func(T) int64   // 单个元素的字节数估算，配合 MemoryBudget 使用
 	}
 )
 
@@ -52,6 +57,21 @@ func WithConcurrencyLimit[T any](limit int) optional.Op[BatchProcessor[T]] {
 	}
 }
 
+func WithDeduplicator[T any](deduper Deduplicator[T]) optional.Op[BatchProcessor[T]] {
+	return func(bp *BatchProcessor[T]) {
+		bp.Deduper = deduper
+	}
+}
+
+// WithMemoryBudget 按上一批的大小为下一批预留预算，不足时在调用 fetcher 前阻塞；单个批次超出 budget 时仍会放行（此时不与其他批次并存）。
+// 设置后批次不再复制，fetcher 不能复用返回的切片
+func WithMemoryBudget[T any](budget int64, size func(T) int64) optional.Op[BatchProcessor[T]] {
+	return func(bp *BatchProcessor[T]) {
+		bp.MemoryBudget = budget
+		bp.SizeFunc = size
+	}
+}
+
 func (bp *BatchProcessor[T]) init() {
 	if bp.BatchSize == 0 {
 		bp.BatchSize = defaultBatchSize
@@ -73,8 +93,12 @@ func (bp *BatchProcessor[T]) Process(ctx context.Context, data []T) error {
 			end = len(data)
 		}
 		startCopy, endCopy := start, end
+		batch := bp.dedup(data[startCopy:endCopy])
+		if len(batch) == 0 {
+			continue
+		}
 		eg.Go(func() error {
-			if err := bp.ProcFunc(ctx, data[startCopy:endCopy]); err != nil {
+			if err := bp.ProcFunc(ctx, batch); err != nil {
 				return fmt.Errorf("error processing batch from index %d to %d: %w", startCopy, endCopy, err)
 			}
 			return nil
@@ -88,66 +112,156 @@ func (bp *BatchProcessor[T]) Process(ctx context.Context, data []T) error {
 	return nil
 }
 
+// ProcessResults 与 Process 相同但某个批次失败不影响其他批次，按批次顺序返回结果；
+// 失败的批次通过 Unwrap 仍可取回其元素，result.Collect 可得到成功批次及合并后的错误
+func (bp *BatchProcessor[T]) ProcessResults(ctx context.Context, data []T) []result.Result[[]T] {
+	bp.init()
+
+	eg := errgroup.Group{}
+	eg.SetLimit(bp.ConcurrencyLimit)
+
+	var (
+		results = make([]result.Result[[]T], (len(data)+bp.BatchSize-1)/bp.BatchSize)
+		empty   = make([]bool, len(results)) // 去重后为空的批次不返回结果
+	)
+	for start := 0; start < len(data); start += bp.BatchSize {
+		end := min(start+bp.BatchSize, len(data))
+		idx := start / bp.BatchSize
+		batch := bp.dedup(data[start:end])
+		if len(batch) == 0 {
+			empty[idx] = true
+			continue
+		}
+		startCopy, endCopy := start, end
+		eg.Go(func() error {
+			err := bp.ProcFunc(ctx, batch)
+			if err != nil {
+				err = fmt.Errorf("error processing batch from index %d to %d: %w", startCopy, endCopy, err)
+			}
+			results[idx] = result.Of(batch, err)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	kept := results[:0]
+	for i, r := range results {
+		if !empty[i] {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
 type batchInfo[T any] struct {
 	batch []T
 	page  int
+	size  int64 // 计入内存预算的字节数
 	err   error
 }
 
+// Stats 一次 ProcessFetcher 运行的统计
+type Stats struct {
+	Batches           int64 // 成功处理的批次数
+	Items             int64 // 成功处理的元素数（去重后）
+	PeakBufferedBytes int64 // 已拉取未处理完（缓冲+处理中）的峰值字节数，需设置 SizeFunc
+}
+
 func (bp *BatchProcessor[T]) ProcessFetcher(ctx context.Context, fetcher Fetcher[T], startPage int) error {
+	_, err := bp.ProcessFetcherWithStats(ctx, fetcher, startPage)
+	return err
+}
+
+func (bp *BatchProcessor[T]) ProcessFetcherWithStats(ctx context.Context, fetcher Fetcher[T], startPage int) (Stats, error) {
 	if fetcher == nil {
-		return fmt.Errorf("no fetcher provided")
+		return Stats{}, fmt.Errorf("no fetcher provided")
 	}
 	bp.init()
 
-	var page = 1
-	if startPage > page {
-		page = startPage
-	}
-
 	var (
+		stats   Stats
 		eg      = errgroup.Group{}
+		budget  = newMemBudget(bp.MemoryBudget)
 		batches = make(chan batchInfo[T], bp.ConcurrencyLimit)
 	)
 	eg.SetLimit(bp.ConcurrencyLimit)
 
-	go func() {
-		defer func() {
-			if err := recover(); err != nil {
-				batches <- batchInfo[T]{err: fmt.Errorf("panic:%v", err)}
-			}
-		}()
-		defer close(batches)
+	go bp.fetch(ctx, fetcher, startPage, budget, batches)
 
-		for ; ; page++ {
-			oneBatch, err := fetcher(ctx, page, bp.BatchSize)
-			if len(oneBatch) == 0 {
-				break
-			}
-			batches <- batchInfo[T]{batch: oneBatch, page: page, err: err}
+	for curBatch := range batches {
+		if bp.MemoryBudget <= 0 {
+			// 设置预算时不复制，避免占用双倍内存，此时 fetcher 不能复用返回的切片
+			curBatch.batch = append([]T(nil), curBatch.batch...)
 		}
-	}()
-
-	for oneBatch := range batches {
-		curBatch := batchInfo[T]{
-			err:   oneBatch.err,
-			page:  oneBatch.page,
-			batch: make([]T, len(oneBatch.batch)),
+		if curBatch.err == nil {
+			if curBatch.batch = bp.dedup(curBatch.batch); len(curBatch.batch) == 0 {
+				budget.release(curBatch.size)
+				continue
+			}
 		}
-		copy(curBatch.batch, oneBatch.batch)
 		eg.Go(func() error {
+			defer budget.release(curBatch.size)
 			if curBatch.err != nil {
 				return fmt.Errorf("error fetching curBatch: %w", curBatch.err)
 			}
 			if err := bp.ProcFunc(ctx, curBatch.batch); err != nil {
 				return fmt.Errorf("error processing curBatch: %w, page: %v", err, curBatch.page)
 			}
+			atomic.AddInt64(&stats.Batches, 1)
+			atomic.AddInt64(&stats.Items, int64(len(curBatch.batch)))
 			return nil
 		})
 	}
 
-	if err := eg.Wait(); err != nil {
-		return err
+	err := eg.Wait()
+	stats.PeakBufferedBytes = budget.peakBytes()
+	return stats, err
+}
+
+// fetch 从 startPage 开始拉取直到返回空批次，结束后关闭 batches；
+// 调用 fetcher 前按上一批的大小预留预算，超出时阻塞，批次比上一批大时多出的部分可能超出预算，但会计入峰值
+func (bp *BatchProcessor[T]) fetch(ctx context.Context, fetcher Fetcher[T], startPage int, budget *memBudget, batches chan<- batchInfo[T]) {
+	var page = 1
+	if startPage > page {
+		page = startPage
 	}
-	return nil
+
+	defer close(batches)
+	defer func() {
+		if err := recover(); err != nil {
+			batches <- batchInfo[T]{err: fmt.Errorf("panic:%v", err)}
+		}
+	}()
+
+	var reserved int64
+	for ; ; page++ {
+		budget.acquire(reserved)
+		oneBatch, err := fetcher(ctx, page, bp.BatchSize)
+		if len(oneBatch) == 0 {
+			budget.release(reserved)
+			break
+		}
+		size := bp.sizeOf(oneBatch)
+		budget.resize(reserved, size)
+		batches <- batchInfo[T]{batch: oneBatch, page: page, size: size, err: err}
+		reserved = size
+	}
+}
+
+func (bp *BatchProcessor[T]) sizeOf(batch []T) (size int64) {
+	if bp.SizeFunc == nil {
+		return 0
+	}
+	for _, item := range batch {
+		size += bp.SizeFunc(item)
+	}
+	return size
+}
+
+// dedup 未设置 Deduper 时原样返回，保持 Process 原地修改 data 的行为
+func (bp *BatchProcessor[T]) dedup(batch []T) []T {
+	if bp.Deduper == nil {
+		return batch
+	}
+	return bp.Deduper.Dedup(batch)
 }
@@ -0,0 +1,212 @@
+package batchprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const defaultSinkBuffer = 1
+
+type (
+	// ErrPolicy sink 处理失败后的策略
+	ErrPolicy int
+
+	// Sink 广播模式下的一个具名处理端，每个 sink 拿到的是批次的独立副本
+	Sink[T any] struct {
+		Name             string
+		ProcFunc         Processor[T]
+		ConcurrencyLimit int       // 同 BatchProcessor.ConcurrencyLimit
+		ErrPolicy        ErrPolicy // 默认 FailFast
+		Buffer           int       // 排队等待处理的批次上限，满了才会阻塞 fetcher，默认 1
+	}
+
+	SinkStats struct {
+		Stats
+		Failed  int64 // 处理失败的批次数
+		Dropped int64 // FailFast 失败后跳过的批次数
+	}
+
+	BroadcastStats struct {
+		PeakBufferedBytes int64
+		Sinks             map[string]*SinkStats
+	}
+
+	// SinkError 标明是哪个 sink 失败
+	SinkError struct {
+		Sink string
+		Err  error
+	}
+)
+
+const (
+	FailFast        ErrPolicy = iota // 首次失败后丢弃该 sink 后续批次
+	ContinueOnError                  // 记录错误并继续处理后续批次
+)
+
+func (e *SinkError) Error() string {
+	return fmt.Sprintf("sink %s: %v", e.Sink, e.Err)
+}
+
+func (e *SinkError) Unwrap() error {
+	return e.Err
+}
+
+type sinkRunner[T any] struct {
+	sink  Sink[T]
+	ch    chan *sharedBatch[T]
+	eg    errgroup.Group
+	stats SinkStats
+
+	mu     sync.Mutex
+	failed bool
+	errs   []error
+}
+
+// sharedBatch 所有 sink 处理完后才释放内存预算
+type sharedBatch[T any] struct {
+	info    batchInfo[T]
+	pending int32
+	budget  *memBudget
+}
+
+func (b *sharedBatch[T]) done() {
+	if atomic.AddInt32(&b.pending, -1) == 0 {
+		b.budget.release(b.info.size)
+	}
+}
+
+// Broadcast 拉取一次，把每个批次分发给所有 sink；各 sink 的并发、错误策略、统计相互独立，
+// 慢 sink 只有在自己的 Buffer 排满后才会阻塞整体拉取
+func (bp *BatchProcessor[T]) Broadcast(ctx context.Context, fetcher Fetcher[T], startPage int, sinks ...Sink[T]) (BroadcastStats, error) {
+	if fetcher == nil {
+		return BroadcastStats{}, fmt.Errorf("no fetcher provided")
+	}
+	if len(sinks) == 0 {
+		return BroadcastStats{}, fmt.Errorf("no sink provided")
+	}
+	bp.init()
+
+	var (
+		names   = make(map[string]struct{}, len(sinks))
+		runners = make([]*sinkRunner[T], 0, len(sinks))
+	)
+	for _, sink := range sinks {
+		if sink.ProcFunc == nil {
+			return BroadcastStats{}, fmt.Errorf("sink %q has no processor", sink.Name)
+		}
+		if _, ok := names[sink.Name]; ok {
+			return BroadcastStats{}, fmt.Errorf("duplicate sink name %q", sink.Name)
+		}
+		names[sink.Name] = struct{}{}
+		runners = append(runners, newSinkRunner(sink))
+	}
+
+	var (
+		wg       sync.WaitGroup
+		fetchErr error
+		budget   = newMemBudget(bp.MemoryBudget)
+		batches  = make(chan batchInfo[T], bp.ConcurrencyLimit)
+	)
+	for _, r := range runners {
+		wg.Add(1)
+		go func(r *sinkRunner[T]) {
+			defer wg.Done()
+			r.run(ctx)
+		}(r)
+	}
+
+	go bp.fetch(ctx, fetcher, startPage, budget, batches)
+
+	for oneBatch := range batches {
+		if oneBatch.err != nil {
+			budget.release(oneBatch.size)
+			fetchErr = errors.Join(fetchErr, fmt.Errorf("error fetching page %v: %w", oneBatch.page, oneBatch.err))
+			continue
+		}
+		if oneBatch.batch = bp.dedup(oneBatch.batch); len(oneBatch.batch) == 0 {
+			budget.release(oneBatch.size)
+			continue
+		}
+		shared := &sharedBatch[T]{info: oneBatch, pending: int32(len(runners)), budget: budget}
+		for _, r := range runners {
+			r.ch <- shared
+		}
+	}
+	for _, r := range runners {
+		close(r.ch)
+	}
+	wg.Wait()
+
+	var (
+		errs  = []error{fetchErr}
+		stats = BroadcastStats{
+			PeakBufferedBytes: budget.peakBytes(),
+			Sinks:             make(map[string]*SinkStats, len(runners)),
+		}
+	)
+	for _, r := range runners {
+		stats.Sinks[r.sink.Name] = &r.stats
+		if len(r.errs) > 0 {
+			errs = append(errs, &SinkError{Sink: r.sink.Name, Err: errors.Join(r.errs...)})
+		}
+	}
+	return stats, errors.Join(errs...)
+}
+
+func newSinkRunner[T any](sink Sink[T]) *sinkRunner[T] {
+	if sink.ConcurrencyLimit <= 0 || sink.ConcurrencyLimit > maxConcurrencyLimit {
+		sink.ConcurrencyLimit = defaultConcurrencyLimit
+	}
+	if sink.Buffer <= 0 {
+		sink.Buffer = defaultSinkBuffer
+	}
+	r := &sinkRunner[T]{
+		sink: sink,
+		ch:   make(chan *sharedBatch[T], sink.Buffer),
+	}
+	r.eg.SetLimit(sink.ConcurrencyLimit)
+	return r
+}
+
+func (r *sinkRunner[T]) run(ctx context.Context) {
+	for shared := range r.ch {
+		if r.stopped() {
+			atomic.AddInt64(&r.stats.Dropped, 1)
+			shared.done()
+			continue
+		}
+		batch := make([]T, len(shared.info.batch))
+		copy(batch, shared.info.batch)
+		page := shared.info.page
+		r.eg.Go(func() error {
+			defer shared.done()
+			if err := r.sink.ProcFunc(ctx, batch); err != nil {
+				atomic.AddInt64(&r.stats.Failed, 1)
+				r.fail(fmt.Errorf("error processing batch: %w, page: %v", err, page))
+				return nil
+			}
+			atomic.AddInt64(&r.stats.Batches, 1)
+			atomic.AddInt64(&r.stats.Items, int64(len(batch)))
+			return nil
+		})
+	}
+	_ = r.eg.Wait()
+}
+
+func (r *sinkRunner[T]) stopped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failed && r.sink.ErrPolicy == FailFast
+}
+
+func (r *sinkRunner[T]) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed = true
+	r.errs = append(r.errs, err)
+}
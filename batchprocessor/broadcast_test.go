@@ -0,0 +1,146 @@
+package batchprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatchProcessor_Broadcast(t *testing.T) {
+	var (
+		data   = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		mu     sync.Mutex
+		dbRows []int
+	)
+	fetcher := func(_ context.Context, page int, pageSize int) ([]int, error) {
+		offset := (page - 1) * pageSize
+		if offset >= len(data) {
+			return nil, nil
+		}
+		return data[offset:min(offset+pageSize, len(data))], nil
+	}
+
+	bp := New(WithBatchSize[int](2))
+	stats, err := bp.Broadcast(context.Background(), fetcher, 0,
+		Sink[int]{
+			Name: "db",
+			ProcFunc: func(_ context.Context, batch []int) error {
+				mu.Lock()
+				defer mu.Unlock()
+				dbRows = append(dbRows, batch...)
+				// 修改副本不影响其他 sink
+				for i := range batch {
+					batch[i] = -1
+				}
+				return nil
+			},
+		},
+		Sink[int]{
+			Name:             "search",
+			ConcurrencyLimit: 2,
+			ErrPolicy:        ContinueOnError,
+			ProcFunc: func(_ context.Context, batch []int) error {
+				if batch[0] < 0 {
+					return fmt.Errorf("saw modified batch")
+				}
+				if batch[0]%3 == 0 {
+					return fmt.Errorf("index %v", batch[0])
+				}
+				return nil
+			},
+		},
+		Sink[int]{
+			Name: "audit",
+			ProcFunc: func(_ context.Context, batch []int) error {
+				return fmt.Errorf("audit down")
+			},
+		},
+	)
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Broadcast() error = %v, want joined SinkErrors", err)
+	}
+	failed := map[string]bool{}
+	for _, e := range joined.Unwrap() {
+		var sinkErr *SinkError
+		if errors.As(e, &sinkErr) {
+			failed[sinkErr.Sink] = true
+		}
+	}
+	if want := map[string]bool{"search": true, "audit": true}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("failed sinks = %v, want %v", failed, want)
+	}
+	if len(dbRows) != len(data) {
+		t.Errorf("db rows = %v", dbRows)
+	}
+	if s := stats.Sinks["db"]; s.Batches != 5 || s.Items != 10 || s.Failed != 0 {
+		t.Errorf("db stats = %+v", s)
+	}
+	// 第3、9 开头的批次失败，继续处理其余批次
+	if s := stats.Sinks["search"]; s.Batches != 3 || s.Failed != 2 {
+		t.Errorf("search stats = %+v", s)
+	}
+	if s := stats.Sinks["audit"]; s.Failed == 0 || s.Failed+s.Dropped != 5 {
+		t.Errorf("audit stats = %+v", s)
+	}
+}
+
+func TestBatchProcessor_BroadcastSlowSink(t *testing.T) {
+	var (
+		data    = make([]int, 20)
+		release = make(chan struct{})
+		fastCnt = make(chan struct{}, len(data))
+	)
+	fetcher := func(_ context.Context, page int, pageSize int) ([]int, error) {
+		offset := (page - 1) * pageSize
+		if offset >= len(data) {
+			return nil, nil
+		}
+		return data[offset:min(offset+pageSize, len(data))], nil
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := New(WithBatchSize[int](1)).Broadcast(context.Background(), fetcher, 0,
+			Sink[int]{Name: "fast", ProcFunc: func(context.Context, []int) error {
+				fastCnt <- struct{}{}
+				return nil
+			}},
+			Sink[int]{Name: "slow", Buffer: 3, ProcFunc: func(context.Context, []int) error {
+				<-release
+				return nil
+			}},
+		)
+		done <- err
+	}()
+
+	// slow 阻塞时，fast 至少能处理 Buffer 以内的批次
+	for i := 0; i < 3; i++ {
+		select {
+		case <-fastCnt:
+		case <-time.After(time.Second):
+			t.Fatalf("fast sink stalled after %d batches", i)
+		}
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBatchProcessor_BroadcastInvalid(t *testing.T) {
+	bp := New[int]()
+	fetcher := func(context.Context, int, int) ([]int, error) { return nil, nil }
+	proc := func(context.Context, []int) error { return nil }
+	if _, err := bp.Broadcast(context.Background(), fetcher, 0); err == nil {
+		t.Error("want error without sinks")
+	}
+	if _, err := bp.Broadcast(context.Background(), fetcher, 0, Sink[int]{Name: "a", ProcFunc: proc}, Sink[int]{Name: "a", ProcFunc: proc}); err == nil {
+		t.Error("want error on duplicate names")
+	}
+}
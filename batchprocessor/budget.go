@@ -0,0 +1,60 @@
+package batchprocessor
+
+import "sync"
+
+// memBudget 字节预算，limit<=0 时只统计峰值不阻塞
+type memBudget struct {
+	limit int64
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	used int64
+	peak int64
+}
+
+func newMemBudget(limit int64) *memBudget {
+	b := &memBudget{limit: limit}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// acquire 占用 n 字节，超出预算时等待其他批次释放；当前无占用时总是放行，避免单个大批次死锁
+func (b *memBudget) acquire(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.limit > 0 && b.used > 0 && b.used+n > b.limit {
+		b.cond.Wait()
+	}
+	b.used += n
+	b.peak = max(b.peak, b.used)
+}
+
+// resize 把一次占用从 from 调整为 to，不等待：数据已在内存中，只记账
+func (b *memBudget) resize(from, to int64) {
+	if from == to {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used += to - from
+	b.peak = max(b.peak, b.used)
+	if to < from {
+		b.cond.Broadcast()
+	}
+}
+
+func (b *memBudget) release(n int64) {
+	if n == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used -= n
+	b.cond.Broadcast()
+}
+
+func (b *memBudget) peakBytes() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.peak
+}
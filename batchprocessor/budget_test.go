@@ -0,0 +1,149 @@
+package batchprocessor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchProcessor_MemoryBudget(t *testing.T) {
+	var (
+		data            = make([]int, 20)
+		running, maxRun int64
+	)
+	bp := New(
+		WithBatchSize[int](2),
+		WithConcurrencyLimit[int](5),
+		WithMemoryBudget(40, func(int) int64 { return 10 }),
+		WithProcessor(func(_ context.Context, batch []int) error {
+			cur := atomic.AddInt64(&running, 1)
+			defer atomic.AddInt64(&running, -1)
+			for {
+				old := atomic.LoadInt64(&maxRun)
+				if cur <= old || atomic.CompareAndSwapInt64(&maxRun, old, cur) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		}),
+	)
+
+	stats, err := bp.ProcessFetcherWithStats(context.Background(), func(_ context.Context, page int, pageSize int) ([]int, error) {
+		offset := (page - 1) * pageSize
+		if offset >= len(data) {
+			return nil, nil
+		}
+		return data[offset:min(offset+pageSize, len(data))], nil
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Batches != 10 || stats.Items != 20 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if stats.PeakBufferedBytes <= 0 || stats.PeakBufferedBytes > 40 {
+		t.Errorf("PeakBufferedBytes = %v, want (0, 40]", stats.PeakBufferedBytes)
+	}
+	if maxRun > 2 {
+		t.Errorf("max concurrent batches = %v, want <= 2", maxRun)
+	}
+}
+
+func TestMemBudget_Oversized(t *testing.T) {
+	b := newMemBudget(10)
+	b.acquire(30) // 无占用时放行
+	done := make(chan struct{})
+	go func() {
+		b.acquire(1)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("acquire should block while over budget")
+	case <-time.After(10 * time.Millisecond):
+	}
+	b.release(30)
+	<-done
+	if b.peakBytes() != 30 {
+		t.Errorf("peak = %v, want 30", b.peakBytes())
+	}
+}
+
+func TestBatchProcessor_MemoryBudgetReservesBeforeFetch(t *testing.T) {
+	var (
+		fetches int64
+		release = make(chan struct{})
+		started = make(chan struct{}, 10)
+	)
+	bp := New(
+		WithBatchSize[int](1),
+		WithConcurrencyLimit[int](5),
+		WithMemoryBudget(20, func(int) int64 { return 10 }),
+		WithProcessor(func(context.Context, []int) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}),
+	)
+
+	done := make(chan Stats)
+	go func() {
+		stats, _ := bp.ProcessFetcherWithStats(context.Background(), func(_ context.Context, page int, _ int) ([]int, error) {
+			atomic.AddInt64(&fetches, 1)
+			if page > 5 {
+				return nil, nil
+			}
+			return []int{page}, nil
+		}, 0)
+		done <- stats
+	}()
+
+	<-started
+	<-started
+	time.Sleep(10 * time.Millisecond)
+	// 两个批次占满预算，第三次拉取前就应阻塞
+	if got := atomic.LoadInt64(&fetches); got != 2 {
+		t.Errorf("fetches while budget full = %v, want 2", got)
+	}
+	close(release)
+	if stats := <-done; stats.PeakBufferedBytes > 20 || stats.Items != 5 {
+		t.Errorf("stats = %+v, want PeakBufferedBytes <= 20", stats)
+	}
+}
+
+func TestBatchProcessor_MemoryBudgetNoCopy(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	bp := New(
+		WithBatchSize[int](2),
+		WithMemoryBudget(100, func(int) int64 { return 1 }),
+		WithProcessor(func(_ context.Context, batch []int) error {
+			batch[0] *= 10 // 未复制时直接修改 fetcher 返回的数据
+			return nil
+		}),
+	)
+	err := bp.ProcessFetcher(context.Background(), func(_ context.Context, page int, pageSize int) ([]int, error) {
+		offset := (page - 1) * pageSize
+		if offset >= len(data) {
+			return nil, nil
+		}
+		return data[offset : offset+pageSize], nil
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[0] != 10 || data[2] != 30 {
+		t.Errorf("data = %v, batches were copied", data)
+	}
+}
+
+func TestMemBudget_Resize(t *testing.T) {
+	b := newMemBudget(10)
+	b.acquire(5)
+	b.resize(5, 12) // 实际批次比预留大，不阻塞但计入峰值
+	b.resize(12, 4)
+	if b.peakBytes() != 12 || b.used != 4 {
+		t.Errorf("peak = %v, used = %v, want 12, 4", b.peakBytes(), b.used)
+	}
+}
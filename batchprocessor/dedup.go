@@ -0,0 +1,158 @@
+package batchprocessor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/1298509345/go-utils-frequently/ds/slice"
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+type (
+	// Deduplicator 批次派发前的去重阶段，返回去重后的批次
+	Deduplicator[T any] interface {
+		Dedup(batch []T) []T
+	}
+
+	// DedupPolicy 同一批次内重复元素的合并策略
+	DedupPolicy int
+
+	// Deduper 基于标识符的窗口去重，并发安全
+	Deduper[T any, ID comparable] struct {
+		identifier slice.Identifier[T, ID]
+		policy     DedupPolicy
+		merge      func(old T, new T) T
+		windowSize int           // 记住最近多少个已派发的ID，<=0 不限制
+		windowTTL  time.Duration // 已派发ID的有效期，<=0 不限制
+		now        func() time.Time
+
+		mu     sync.Mutex
+		seen   map[ID]time.Time
+		window []dedupEntry[ID]
+	}
+
+	dedupEntry[ID comparable] struct {
+		id ID
+		at time.Time
+	}
+)
+
+const (
+	KeepFirst DedupPolicy = iota // 保留首次出现的元素
+	KeepLast                     // 保留最后出现的元素，位置沿用首次出现的位置
+	MergeFunc                    // 使用 WithDedupMerge 设置的合并函数
+)
+
+// NewDeduper 不设置窗口时只对批次内去重；设置窗口后，窗口内已派发过的ID会被直接丢弃（之前的元素已经交给 Processor，无法再合并）
+func NewDeduper[T any, ID comparable](identifier slice.Identifier[T, ID], options ...optional.Op[Deduper[T, ID]]) *Deduper[T, ID] {
+	d := &Deduper[T, ID]{
+		identifier: identifier,
+		now:        time.Now,
+		seen:       make(map[ID]time.Time),
+	}
+	for _, option := range options {
+		option(d)
+	}
+	if d.policy == MergeFunc && d.merge == nil {
+		d.policy = KeepFirst
+	}
+	return d
+}
+
+// WithDedupWindow size 和 ttl 任一 >0 即开启跨批次窗口
+func WithDedupWindow[T any, ID comparable](size int, ttl time.Duration) optional.Op[Deduper[T, ID]] {
+	return func(d *Deduper[T, ID]) {
+		d.windowSize = size
+		d.windowTTL = ttl
+	}
+}
+
+func WithDedupPolicy[T any, ID comparable](policy DedupPolicy) optional.Op[Deduper[T, ID]] {
+	return func(d *Deduper[T, ID]) {
+		d.policy = policy
+	}
+}
+
+// WithDedupMerge 类似 _map.MergeByFunc 的合并函数，同时把策略设置为 MergeFunc
+func WithDedupMerge[T any, ID comparable](merge func(old T, new T) T) optional.Op[Deduper[T, ID]] {
+	return func(d *Deduper[T, ID]) {
+		d.policy = MergeFunc
+		d.merge = merge
+	}
+}
+
+func WithDedupClock[T any, ID comparable](now func() time.Time) optional.Op[Deduper[T, ID]] {
+	return func(d *Deduper[T, ID]) {
+		d.now = now
+	}
+}
+
+func (d *Deduper[T, ID]) windowed() bool {
+	return d.windowSize > 0 || d.windowTTL > 0
+}
+
+func (d *Deduper[T, ID]) Dedup(batch []T) []T {
+	var (
+		idx    = make(map[ID]int, len(batch))
+		result = make([]T, 0, len(batch))
+	)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.evict(now)
+
+	for _, item := range batch {
+		id := d.identifier(item)
+		if _, ok := d.seen[id]; ok {
+			continue
+		}
+		i, ok := idx[id]
+		if !ok {
+			idx[id] = len(result)
+			result = append(result, item)
+			continue
+		}
+		switch d.policy {
+		case KeepLast:
+			result[i] = item
+		case MergeFunc:
+			result[i] = d.merge(result[i], item)
+		}
+	}
+
+	if d.windowed() {
+		for _, item := range result {
+			id := d.identifier(item)
+			d.seen[id] = now
+			d.window = append(d.window, dedupEntry[ID]{id: id, at: now})
+		}
+		d.evict(now)
+	}
+	return result
+}
+
+// Reset 清空窗口
+func (d *Deduper[T, ID]) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	clear(d.seen)
+	d.window = d.window[:0]
+}
+
+func (d *Deduper[T, ID]) evict(now time.Time) {
+	var drop int
+	for drop < len(d.window) {
+		expired := d.windowTTL > 0 && now.Sub(d.window[drop].at) >= d.windowTTL
+		overflow := d.windowSize > 0 && len(d.window)-drop > d.windowSize
+		if !expired && !overflow {
+			break
+		}
+		delete(d.seen, d.window[drop].id)
+		drop++
+	}
+	if drop > 0 {
+		d.window = append(d.window[:0], d.window[drop:]...)
+	}
+}
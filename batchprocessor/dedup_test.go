@@ -0,0 +1,123 @@
+package batchprocessor
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+type dedupItem struct {
+	ID  int
+	Ver int
+}
+
+func dedupItemID(i dedupItem) int { return i.ID }
+
+func TestDeduper_Dedup(t *testing.T) {
+	type testCase struct {
+		name    string
+		options []optional.Op[Deduper[dedupItem, int]]
+		input   []dedupItem
+		want    []dedupItem
+	}
+
+	input := []dedupItem{{1, 1}, {2, 1}, {1, 2}, {3, 1}, {2, 2}}
+	tests := []testCase{
+		{
+			name:  "keep first",
+			input: input,
+			want:  []dedupItem{{1, 1}, {2, 1}, {3, 1}},
+		},
+		{
+			name:    "keep last",
+			options: []optional.Op[Deduper[dedupItem, int]]{WithDedupPolicy[dedupItem, int](KeepLast)},
+			input:   input,
+			want:    []dedupItem{{1, 2}, {2, 2}, {3, 1}},
+		},
+		{
+			name: "merge",
+			options: []optional.Op[Deduper[dedupItem, int]]{
+				WithDedupMerge[dedupItem, int](func(old, new dedupItem) dedupItem {
+					return dedupItem{ID: old.ID, Ver: old.Ver + new.Ver}
+				}),
+			},
+			input: input,
+			want:  []dedupItem{{1, 3}, {2, 3}, {3, 1}},
+		},
+		{
+			name:  "empty",
+			input: nil,
+			want:  []dedupItem{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeduper(dedupItemID, tt.options...)
+			if got := d.Dedup(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dedup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeduper_Window(t *testing.T) {
+	var now = time.Unix(0, 0)
+	d := NewDeduper(dedupItemID,
+		WithDedupWindow[dedupItem, int](2, time.Minute),
+		WithDedupClock[dedupItem, int](func() time.Time { return now }),
+	)
+
+	if got := d.Dedup([]dedupItem{{1, 1}, {2, 1}}); len(got) != 2 {
+		t.Fatalf("first batch = %v", got)
+	}
+	// 1、2 都在窗口内
+	if got := d.Dedup([]dedupItem{{1, 2}, {2, 2}, {3, 1}}); !reflect.DeepEqual(got, []dedupItem{{3, 1}}) {
+		t.Fatalf("second batch = %v", got)
+	}
+	// 窗口大小为2，1 已被挤出
+	if got := d.Dedup([]dedupItem{{1, 3}, {3, 2}}); !reflect.DeepEqual(got, []dedupItem{{1, 3}}) {
+		t.Fatalf("third batch = %v", got)
+	}
+	// 全部过期
+	now = now.Add(time.Minute)
+	if got := d.Dedup([]dedupItem{{1, 4}, {3, 3}}); len(got) != 2 {
+		t.Fatalf("fourth batch = %v", got)
+	}
+}
+
+func TestBatchProcessor_Dedup(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		got []dedupItem
+	)
+	bp := New(
+		WithBatchSize[dedupItem](3),
+		WithProcessor(func(_ context.Context, batch []dedupItem) error {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, batch...)
+			return nil
+		}),
+		WithDeduplicator[dedupItem](NewDeduper(dedupItemID, WithDedupWindow[dedupItem, int](10, 0))),
+	)
+
+	data := []dedupItem{{1, 1}, {1, 2}, {2, 1}, {2, 2}, {3, 1}, {1, 3}, {4, 1}}
+	err := bp.ProcessFetcher(context.Background(), func(_ context.Context, page int, pageSize int) ([]dedupItem, error) {
+		offset := (page - 1) * pageSize
+		if offset >= len(data) {
+			return nil, nil
+		}
+		return data[offset:min(offset+pageSize, len(data))], nil
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dedupItem{{1, 1}, {2, 1}, {3, 1}, {4, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("processed = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,54 @@
+package batchprocessor
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1298509345/go-utils-frequently/result"
+)
+
+func TestBatchProcessor_ProcessResults(t *testing.T) {
+	errBatch := errors.New("bad batch")
+	bp := New(
+		WithBatchSize[int](2),
+		WithConcurrencyLimit[int](3),
+		WithProcessor(func(_ context.Context, batch []int) error {
+			if batch[0] == 3 {
+				return errBatch
+			}
+			return nil
+		}),
+	)
+
+	results := bp.ProcessResults(context.Background(), []int{1, 2, 3, 4, 5, 6, 7})
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	failed, err := results[1].Unwrap()
+	if !errors.Is(err, errBatch) || !reflect.DeepEqual(failed, []int{3, 4}) {
+		t.Errorf("results[1] = %v, %v, want failed batch [3 4]", failed, err)
+	}
+
+	ok, err := result.Collect(results)
+	if want := [][]int{{1, 2}, {5, 6}, {7}}; !reflect.DeepEqual(ok, want) {
+		t.Errorf("Collect() = %v, want %v", ok, want)
+	}
+	if !errors.Is(err, errBatch) {
+		t.Errorf("Collect() err = %v", err)
+	}
+
+	t.Run("dedup drops empty batches", func(t *testing.T) {
+		bp := New(
+			WithBatchSize[int](2),
+			WithProcessor(func(context.Context, []int) error { return nil }),
+			WithDeduplicator[int](NewDeduper(func(v int) int { return v }, WithDedupWindow[int, int](10, 0))),
+		)
+		results := bp.ProcessResults(context.Background(), []int{1, 2, 1, 2, 3})
+		got, err := result.Collect(results)
+		if err != nil || !reflect.DeepEqual(got, [][]int{{1, 2}, {3}}) {
+			t.Errorf("ProcessResults() = %v, %v", got, err)
+		}
+	})
+}
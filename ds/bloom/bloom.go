@@ -0,0 +1,80 @@
+package bloom
+
+import (
+	"hash/maphash"
+	"math"
+)
+
+// Filter 布隆过滤器，非并发安全；Test 返回 false 时一定不存在，返回 true 时有 fpRate 概率误判
+type Filter struct {
+	bits []uint64
+	m    uint64 // 位数
+	k    uint64 // 哈希函数个数
+
+	seed1, seed2 maphash.Seed
+}
+
+// New 按预期元素数 n 和误判率 fpRate 计算位数和哈希函数个数
+func New(n int, fpRate float64) *Filter {
+	if n <= 0 {
+		n = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.01
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	return NewWithSize(m, max(k, 1))
+}
+
+// NewWithSize 直接指定位数 m 和哈希函数个数 k
+func NewWithSize(m, k uint64) *Filter {
+	m = max(m, 64)
+	return &Filter{
+		bits:  make([]uint64, (m+63)/64),
+		m:     m,
+		k:     max(k, 1),
+		seed1: maphash.MakeSeed(),
+		seed2: maphash.MakeSeed(),
+	}
+}
+
+func (f *Filter) Add(key []byte) {
+	h1, h2 := f.hash(key)
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+func (f *Filter) Test(key []byte) bool {
+	h1, h2 := f.hash(key)
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAndAdd 返回加入前是否（可能）已存在
+func (f *Filter) TestAndAdd(key []byte) bool {
+	exist := f.Test(key)
+	if !exist {
+		f.Add(key)
+	}
+	return exist
+}
+
+func (f *Filter) Reset() {
+	clear(f.bits)
+}
+
+// hash 双重哈希：h1、h2 用两个独立的种子计算，第 i 个位置为 (h1 + i*h2) % m；
+// h2 落在 [1, m) 内，避免所有位置相同
+func (f *Filter) hash(key []byte) (uint64, uint64) {
+	h1 := maphash.Bytes(f.seed1, key)
+	h2 := maphash.Bytes(f.seed2, key)
+	return h1, h2%(f.m-1) + 1
+}
@@ -0,0 +1,53 @@
+package bloom
+
+import (
+	"math/bits"
+	"strconv"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	const n = 10000
+	f := New(n, 0.01)
+	for i := 0; i < n; i++ {
+		f.Add([]byte(strconv.Itoa(i)))
+	}
+	for i := 0; i < n; i++ {
+		if !f.Test([]byte(strconv.Itoa(i))) {
+			t.Fatalf("Test(%d) = false after Add", i)
+		}
+	}
+
+	var fp int
+	for i := n; i < 2*n; i++ {
+		if f.Test([]byte(strconv.Itoa(i))) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / n; rate > 0.03 {
+		t.Errorf("false positive rate = %v", rate)
+	}
+
+	f.Reset()
+	if f.TestAndAdd([]byte("1")) {
+		t.Error("TestAndAdd() = true after Reset")
+	}
+	if !f.TestAndAdd([]byte("1")) {
+		t.Error("TestAndAdd() = false for added key")
+	}
+}
+
+func TestFilter_NonPowerOfTwoSize(t *testing.T) {
+	// m 不是2的幂时每个 key 仍应落在多个不同位置
+	for i := 0; i < 1000; i++ {
+		f := NewWithSize(1000, 5)
+		f.Add([]byte(strconv.Itoa(i)))
+		var set int
+		for _, w := range f.bits {
+			set += bits.OnesCount64(w)
+		}
+		if set < 2 {
+			t.Fatalf("key %d set %d bits, want at least 2", i, set)
+		}
+	}
+}
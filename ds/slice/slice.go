@@ -1,6 +1,10 @@
 package slice
 
-import "slices"
+import (
+	"slices"
+
+	"github.com/1298509345/go-utils-frequently/result"
+)
 
 type (
 	Identifier[E any, ID comparable] func(E) ID
@@ -139,6 +143,20 @@ func Convert[T any, R any](slice []T, converter Converter[T, R]) ([]R, error) {
 	return result, nil
 }
 
+// ConvertResult 与 Convert 相同但不在第一个错误处停止，每个元素的结果按原顺序返回
+func ConvertResult[T any, R any](slice []T, converter Converter[T, R]) []result.Result[R] {
+	results := make([]result.Result[R], len(slice))
+	for i, v := range slice {
+		results[i] = result.Of(converter(v))
+	}
+	return results
+}
+
+// ConvertPartial 返回所有转换成功的元素，失败元素的错误合并返回
+func ConvertPartial[T any, R any](slice []T, converter Converter[T, R]) ([]R, error) {
+	return result.Collect(ConvertResult(slice, converter))
+}
+
 func ToMap[E any, ID comparable](sl []E, id Identifier[E, ID]) map[ID]E {
 	return GroupByFunc(sl, id, func(e E, _ E) E { return e })
 }
@@ -3,6 +3,7 @@ package slice
 import (
 	"encoding/json"
 	"reflect"
+	"slices"
 	"strconv"
 	"testing"
 )
@@ -73,6 +74,40 @@ func TestSliceConvert(t *testing.T) {
 	}
 }
 
+func TestSliceConvertResult(t *testing.T) {
+	atoi := func(s string) (int, error) { return strconv.Atoi(s) }
+	tests := []struct {
+		name    string
+		slice   []string
+		want    []int
+		wantErr []bool
+	}{
+		{name: "all ok", slice: []string{"1", "2"}, want: []int{1, 2}, wantErr: []bool{false, false}},
+		{name: "partial", slice: []string{"1", "x", "3", "y"}, want: []int{1, 3}, wantErr: []bool{false, true, false, true}},
+		{name: "empty", slice: nil, want: []int{}, wantErr: []bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ConvertResult(tt.slice, atoi)
+			gotErr := make([]bool, len(results))
+			for i, r := range results {
+				gotErr[i] = !r.IsOk()
+			}
+			if !reflect.DeepEqual(gotErr, tt.wantErr) {
+				t.Errorf("ConvertResult() errors = %v, want %v", gotErr, tt.wantErr)
+			}
+
+			got, err := ConvertPartial(tt.slice, atoi)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertPartial() got = %v, want %v", got, tt.want)
+			}
+			if (err != nil) != slices.Contains(tt.wantErr, true) {
+				t.Errorf("ConvertPartial() error = %v", err)
+			}
+		})
+	}
+}
+
 func TestSliceDifferenceSet(t *testing.T) {
 	type args[E any, ID comparable] struct {
 		s1         []E
@@ -0,0 +1,145 @@
+package optional
+
+import "reflect"
+
+// Cloner 自定义 *T 的深拷贝，NewCopy 系列优先使用
+type Cloner[T any] interface {
+	Clone() *T
+}
+
+// NewCopy 与 New 相同，但在 def 的深拷贝上应用 ops，def 本身不会被修改，可安全共享包级默认值
+func NewCopy[T any](def *T, ops ...Op[T]) *T {
+	return New(DeepCopy(def), ops...)
+}
+
+// NewCopyWithErr 与 NewWithErr 相同，但不修改 def
+func NewCopyWithErr[T any](def *T, ops ...Op[T]) (*T, error) {
+	return NewWithErr(DeepCopy(def), ops...)
+}
+
+// NewCopyE 与 NewE 相同，但不修改 def
+func NewCopyE[T any](def *T, ops ...Applier[T]) (*T, error) {
+	return NewE(DeepCopy(def), ops...)
+}
+
+// DeepCopy 实现了 Cloner 时调用 Clone，否则按反射复制 *t：
+//   - 切片、map、数组、接口及导出字段逐层复制；map 的 key、未导出字段、func、chan 按值浅拷贝；
+//   - 指针只在指向纯数据类型时复制所指的值。纯数据类型由基本类型、字符串，以及由它们组成的切片、map、数组、指针
+//     和全部字段导出的结构体构成；其他指针（如 *http.Client、*sql.DB、*time.Location、日志对象）按引用共享；
+//   - 指针环在副本中保持同样的结构
+func DeepCopy[T any](t *T) *T {
+	if t == nil {
+		return nil
+	}
+	if c, ok := any(t).(Cloner[T]); ok {
+		return c.Clone()
+	}
+	cp := &copier{seen: map[visit]reflect.Value{}, plain: map[reflect.Type]bool{}}
+	return cp.copyRoot(reflect.ValueOf(t)).Interface().(*T)
+}
+
+// visit 已复制过的指针或 map，避免环导致无限递归
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type copier struct {
+	seen  map[visit]reflect.Value
+	plain map[reflect.Type]bool
+}
+
+// copyRoot 根指针总是复制，否则 def 会被修改
+func (c *copier) copyRoot(v reflect.Value) reflect.Value {
+	cp := reflect.New(v.Type().Elem())
+	c.seen[visit{ptr: v.Pointer(), typ: v.Type()}] = cp
+	cp.Elem().Set(c.copy(v.Elem()))
+	return cp
+}
+
+func (c *copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || !c.isPlain(v.Type().Elem()) {
+			return v
+		}
+		key := visit{ptr: v.Pointer(), typ: v.Type()}
+		if cp, ok := c.seen[key]; ok {
+			return cp
+		}
+		cp := reflect.New(v.Type().Elem())
+		c.seen[key] = cp
+		cp.Elem().Set(c.copy(v.Elem()))
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := visit{ptr: v.Pointer(), typ: v.Type()}
+		if cp, ok := c.seen[key]; ok {
+			return cp
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.seen[key] = cp
+		for iter := v.MapRange(); iter.Next(); {
+			cp.SetMapIndex(iter.Key(), c.copy(iter.Value())) // key 保持原样，指针 key 的相等性不变
+		}
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return cp
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return cp
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(c.copy(v.Elem()))
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v) // 未导出字段浅拷贝
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				cp.Field(i).Set(c.copy(v.Field(i)))
+			}
+		}
+		return cp
+	default:
+		return v
+	}
+}
+
+// isPlain 类型是否只包含可以安全复制的数据，递归类型在判断过程中按纯数据处理
+func (c *copier) isPlain(t reflect.Type) bool {
+	if plain, ok := c.plain[t]; ok {
+		return plain
+	}
+	c.plain[t] = true
+	plain := true
+	switch t.Kind() {
+	case reflect.Interface, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		plain = false
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		plain = c.isPlain(t.Elem())
+	case reflect.Map:
+		plain = c.isPlain(t.Key()) && c.isPlain(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && plain; i++ {
+			plain = t.Field(i).IsExported() && c.isPlain(t.Field(i).Type)
+		}
+	}
+	c.plain[t] = plain
+	return plain
+}
@@ -0,0 +1,216 @@
+package optional
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type (
+	endpoint struct {
+		Host string
+		Port int
+	}
+
+	serverConfig struct {
+		Name     string
+		Tags     []string
+		Headers  map[string][]string
+		Primary  *endpoint
+		Backups  [2]*endpoint
+		Extra    any
+		Timeout  time.Duration
+		Started  time.Time
+		Hook     func()
+		Route    *route
+		internal []int
+	}
+
+	// route 纯数据的环形链表
+	route struct {
+		Path string
+		Next *route
+	}
+
+	clonedConfig struct {
+		Values []int
+		clones *int
+	}
+)
+
+func (c *clonedConfig) Clone() *clonedConfig {
+	*c.clones++
+	return &clonedConfig{Values: append([]int(nil), c.Values...), clones: c.clones}
+}
+
+func newServerDefault() *serverConfig {
+	def := &serverConfig{
+		Name:     "default",
+		Tags:     []string{"a", "b"},
+		Headers:  map[string][]string{"Accept": {"json"}},
+		Primary:  &endpoint{Host: "localhost", Port: 80},
+		Backups:  [2]*endpoint{{Host: "b1", Port: 81}},
+		Extra:    &endpoint{Host: "extra"},
+		Timeout:  time.Second,
+		Started:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+		internal: []int{1},
+		Route:    &route{Path: "/a"},
+	}
+	def.Route.Next = &route{Path: "/b", Next: def.Route}
+	return def
+}
+
+// mutateAll 修改 serverConfig 中所有引用类型指向的内容
+func mutateAll() Op[serverConfig] {
+	return func(c *serverConfig) {
+		c.Name = "changed"
+		c.Tags[0] = "x"
+		c.Tags = append(c.Tags, "c")
+		c.Headers["Accept"][0] = "xml"
+		c.Headers["New"] = nil
+		c.Primary.Port = 8080
+		c.Backups[0].Host = "changed"
+		c.Backups[1] = &endpoint{}
+		c.Extra.(*endpoint).Host = "changed"
+		c.Timeout = time.Minute
+		c.Route.Next.Path = "changed"
+	}
+}
+
+func TestNewCopy(t *testing.T) {
+	want := newServerDefault()
+	constructors := []struct {
+		name string
+		new  func(def *serverConfig, ops ...Op[serverConfig]) *serverConfig
+	}{
+		{"NewCopy", NewCopy[serverConfig]},
+		{"NewCopyWithErr", func(def *serverConfig, ops ...Op[serverConfig]) *serverConfig {
+			got, _ := NewCopyWithErr(def, ops...)
+			return got
+		}},
+		{"NewCopyE", func(def *serverConfig, ops ...Op[serverConfig]) *serverConfig {
+			appliers := make([]Applier[serverConfig], len(ops))
+			for i, op := range ops {
+				appliers[i] = op
+			}
+			got, _ := NewCopyE(def, appliers...)
+			return got
+		}},
+	}
+	for _, tt := range constructors {
+		t.Run(tt.name, func(t *testing.T) {
+			def := newServerDefault()
+			got := tt.new(def, mutateAll())
+
+			if !reflect.DeepEqual(def.Tags, want.Tags) || !reflect.DeepEqual(def.Headers, want.Headers) ||
+				*def.Primary != *want.Primary || *def.Backups[0] != *want.Backups[0] || def.Backups[1] != nil ||
+				*def.Extra.(*endpoint) != *want.Extra.(*endpoint) || def.Name != want.Name || def.Timeout != want.Timeout ||
+				def.Route.Next.Path != "/b" {
+				t.Errorf("default modified: %+v", def)
+			}
+			if got.Name != "changed" || got.Primary.Port != 8080 || got.Timeout != time.Minute {
+				t.Errorf("options not applied: %+v", got)
+			}
+			if got.Route.Next.Next != got.Route || got.Route == def.Route {
+				t.Error("pointer cycle not preserved in copy")
+			}
+			if !got.Started.Equal(def.Started) || got.Started.Location() != time.Local {
+				t.Errorf("Started = %v", got.Started)
+			}
+		})
+	}
+}
+
+func TestNew_MutatesDefault(t *testing.T) {
+	// New 在原地修改 def，共享默认值时需要使用 NewCopy
+	def := newServerDefault()
+	New(def, mutateAll())
+	if def.Name != "changed" {
+		t.Errorf("New() did not modify def in place")
+	}
+}
+
+func TestNewCopy_SharedDefault(t *testing.T) {
+	def := newServerDefault()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			NewCopy(def, mutateAll())
+		}()
+	}
+	wg.Wait()
+	if !reflect.DeepEqual(def.Tags, []string{"a", "b"}) || def.Primary.Port != 80 {
+		t.Errorf("default modified: %+v", def)
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	if got := DeepCopy[serverConfig](nil); got != nil {
+		t.Errorf("DeepCopy(nil) = %v", got)
+	}
+
+	t.Run("unexported shallow", func(t *testing.T) {
+		def := newServerDefault()
+		got := DeepCopy(def)
+		got.internal[0] = 2
+		if def.internal[0] != 2 {
+			t.Error("unexported field should be copied shallowly")
+		}
+	})
+
+	t.Run("cloner", func(t *testing.T) {
+		var clones int
+		def := &clonedConfig{Values: []int{1, 2}, clones: &clones}
+		got := NewCopy(def, func(c *clonedConfig) { c.Values[0] = 9 })
+		if clones != 1 || def.Values[0] != 1 || got.Values[0] != 9 {
+			t.Errorf("clones = %d, def = %v, got = %v", clones, def.Values, got.Values)
+		}
+	})
+
+	t.Run("map pointer keys kept", func(t *testing.T) {
+		key := &endpoint{}
+		type keyed struct{ M map[*endpoint]int }
+		got := DeepCopy(&keyed{M: map[*endpoint]int{key: 1}})
+		if got.M[key] != 1 {
+			t.Error("pointer key identity lost")
+		}
+	})
+}
+
+func TestDeepCopy_SharedHandles(t *testing.T) {
+	type clientConfig struct {
+		Client  *http.Client
+		Loc     *time.Location
+		Logger  *slog.Logger
+		Clients []*http.Client
+		Target  *endpoint
+	}
+	def := &clientConfig{
+		Client:  &http.Client{Transport: http.DefaultTransport},
+		Loc:     time.UTC,
+		Logger:  slog.Default(),
+		Clients: []*http.Client{http.DefaultClient},
+		Target:  &endpoint{Host: "a"},
+	}
+	got := NewCopy(def, func(c *clientConfig) { c.Target.Host = "b" })
+
+	// 指向含 func、接口或未导出字段类型的指针按引用共享
+	if got.Client != def.Client || got.Client.Transport != http.DefaultTransport {
+		t.Error("*http.Client should be shared")
+	}
+	if got.Loc != time.UTC || got.Logger != def.Logger || got.Clients[0] != http.DefaultClient {
+		t.Error("handles should be shared")
+	}
+	if &got.Clients[0] == &def.Clients[0] {
+		t.Error("slice backing array should be copied")
+	}
+	// 纯数据指针仍然复制
+	if got.Target == def.Target || def.Target.Host != "a" {
+		t.Errorf("plain pointer not copied, def.Target = %+v", def.Target)
+	}
+}
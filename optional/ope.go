@@ -0,0 +1,150 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+type (
+	// OpE 可以拒绝参数的 option
+	OpE[T any] func(*T) error
+
+	// Applier Op 和 OpE 的公共接口，需要 OpE 的地方都可以传 Op
+	Applier[T any] interface {
+		Apply(*T) error
+	}
+
+	// OptionError NewE 中第一个失败的 option，Index 为其在参数中的位置
+	OptionError struct {
+		Index int
+		Name  string
+		Err   error
+	}
+
+	named[T any] struct {
+		name string
+		op   Applier[T]
+	}
+)
+
+// Apply nil 的 Op 不做任何事
+func (o Op[T]) Apply(t *T) error {
+	if o != nil {
+		o(t)
+	}
+	return nil
+}
+
+// Apply nil 的 OpE 不做任何事
+func (o OpE[T]) Apply(t *T) error {
+	if o == nil {
+		return nil
+	}
+	return o(t)
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("optional: option #%d (%s): %v", e.Index, e.Name, e.Err)
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// NewE 依次应用 ops，遇到第一个失败的 option 即停止并返回 *OptionError；全部成功后与 NewWithErr 一样执行 Validate。
+// nil 的 option（包括 nil 的 Op、OpE）会被跳过
+func NewE[T any](def *T, ops ...Applier[T]) (*T, error) {
+	if def == nil {
+		return nil, nil
+	}
+	if err := apply(def, ops); err != nil {
+		return nil, err
+	}
+	if v, ok := any(def).(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
+// Named 为 option 指定出错时显示的名字，默认使用构造它的函数名
+func Named[T any](name string, op Applier[T]) Applier[T] {
+	return named[T]{name: name, op: op}
+}
+
+func (n named[T]) Apply(t *T) error {
+	if n.op == nil {
+		return nil
+	}
+	return n.op.Apply(t)
+}
+
+// Compose 把多个 option 合并为一个，按顺序应用并在第一个错误处停止
+func Compose[T any](ops ...Applier[T]) OpE[T] {
+	return func(t *T) error {
+		return apply(t, ops)
+	}
+}
+
+// Group 同 Compose，出错时以 name 标识整组
+func Group[T any](name string, ops ...Applier[T]) Applier[T] {
+	return Named[T](name, Compose(ops...))
+}
+
+// When cond 为 true 时才应用 op
+func When[T any](cond bool, op Applier[T]) OpE[T] {
+	return func(t *T) error {
+		if !cond || op == nil {
+			return nil
+		}
+		return op.Apply(t)
+	}
+}
+
+// Unless cond 为 false 时才应用 op
+func Unless[T any](cond bool, op Applier[T]) OpE[T] {
+	return When(!cond, op)
+}
+
+func apply[T any](t *T, ops []Applier[T]) error {
+	for i, op := range ops {
+		if op == nil {
+			continue
+		}
+		if err := op.Apply(t); err != nil {
+			return &OptionError{Index: i, Name: opName(op), Err: err}
+		}
+	}
+	return nil
+}
+
+// opName 形如 "batchprocessor.WithBatchSize"，取自构造闭包的函数名
+func opName[T any](op Applier[T]) string {
+	if n, ok := op.(named[T]); ok {
+		return n.name
+	}
+	v := reflect.ValueOf(op)
+	if v.Kind() != reflect.Func {
+		return v.Type().String()
+	}
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i] // 泛型实例化
+	}
+	for {
+		i := strings.LastIndex(name, ".func")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return name
+}
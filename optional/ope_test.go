@@ -0,0 +1,152 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type batchConfig struct {
+	Size  int
+	Limit int
+	Tags  []string
+}
+
+var errInvalid = errors.New("invalid")
+
+func WithSize(size int) OpE[batchConfig] {
+	return func(c *batchConfig) error {
+		if size <= 0 {
+			return fmt.Errorf("size %d: %w", size, errInvalid)
+		}
+		c.Size = size
+		return nil
+	}
+}
+
+func WithLimit(limit int) OpE[batchConfig] {
+	return func(c *batchConfig) error {
+		if limit <= 0 {
+			return fmt.Errorf("limit %d: %w", limit, errInvalid)
+		}
+		c.Limit = limit
+		return nil
+	}
+}
+
+func WithTag(tag string) Op[batchConfig] {
+	return func(c *batchConfig) {
+		c.Tags = append(c.Tags, tag)
+	}
+}
+
+func TestNewE(t *testing.T) {
+	tests := []struct {
+		name      string
+		ops       []Applier[batchConfig]
+		want      batchConfig
+		wantIndex int
+		wantName  string
+	}{
+		{
+			name: "ok mixed Op and OpE",
+			ops:  []Applier[batchConfig]{WithSize(10), WithTag("a"), WithLimit(2)},
+			want: batchConfig{Size: 10, Limit: 2, Tags: []string{"a"}},
+		},
+		{
+			name:      "stop at first failure",
+			ops:       []Applier[batchConfig]{WithTag("a"), WithSize(-5), WithLimit(-1)},
+			wantIndex: 1,
+			wantName:  "optional.WithSize",
+		},
+		{
+			name:      "named",
+			ops:       []Applier[batchConfig]{Named[batchConfig]("batch size", WithSize(0))},
+			wantIndex: 0,
+			wantName:  "batch size",
+		},
+		{
+			name:      "group",
+			ops:       []Applier[batchConfig]{WithSize(1), Group("limits", WithTag("x"), WithLimit(0))},
+			wantIndex: 1,
+			wantName:  "limits",
+		},
+		{
+			name:      "compose",
+			ops:       []Applier[batchConfig]{Compose[batchConfig](WithSize(0))},
+			wantIndex: 0,
+			wantName:  "optional.Compose",
+		},
+		{
+			name: "when unless",
+			ops: []Applier[batchConfig]{
+				When[batchConfig](true, WithTag("when")),
+				When[batchConfig](false, WithSize(-1)),
+				Unless[batchConfig](true, WithLimit(-1)),
+				Unless[batchConfig](false, WithTag("unless")),
+			},
+			want: batchConfig{Tags: []string{"when", "unless"}},
+		},
+		{
+			name: "nil skipped",
+			ops:  []Applier[batchConfig]{nil, WithSize(3)},
+			want: batchConfig{Size: 3},
+		},
+		{
+			name: "typed nil skipped",
+			ops: []Applier[batchConfig]{
+				Op[batchConfig](nil),
+				OpE[batchConfig](nil),
+				Named[batchConfig]("nil", nil),
+				When[batchConfig](true, Op[batchConfig](nil)),
+				When[batchConfig](true, nil),
+				Compose(Applier[batchConfig](OpE[batchConfig](nil)), WithTag("a")),
+			},
+			want: batchConfig{Tags: []string{"a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewE(&batchConfig{}, tt.ops...)
+			if tt.wantName == "" {
+				if err != nil {
+					t.Fatalf("NewE() error = %v", err)
+				}
+				if fmt.Sprint(*got) != fmt.Sprint(tt.want) {
+					t.Errorf("NewE() = %+v, want %+v", *got, tt.want)
+				}
+				return
+			}
+
+			var optErr *OptionError
+			if !errors.As(err, &optErr) {
+				t.Fatalf("NewE() error = %v, want *OptionError", err)
+			}
+			if optErr.Index != tt.wantIndex || optErr.Name != tt.wantName {
+				t.Errorf("OptionError = #%d %q, want #%d %q", optErr.Index, optErr.Name, tt.wantIndex, tt.wantName)
+			}
+			if !errors.Is(err, errInvalid) || !strings.Contains(err.Error(), tt.wantName) {
+				t.Errorf("NewE() error = %v", err)
+			}
+			if got != nil {
+				t.Errorf("NewE() = %+v, want nil on error", got)
+			}
+		})
+	}
+}
+
+func TestNewE_Validate(t *testing.T) {
+	if got, err := NewE[UserConfig](nil, WithName("a")); got != nil || err != nil {
+		t.Errorf("NewE(nil) = %v, %v", got, err)
+	}
+
+	// Op 可直接传给 NewE
+	got, err := NewE(&UserConfig{}, WithName("Alice"), WithAge(25))
+	if err != nil || got.Name != "Alice" || got.Age != 25 {
+		t.Errorf("NewE() = %+v, %v", got, err)
+	}
+	if _, err := NewE(&UserConfig{}, WithName("Alice")); err == nil {
+		t.Error("NewE() want Validate error")
+	}
+}
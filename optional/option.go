@@ -0,0 +1,133 @@
+package optional
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// Option 可能有值也可能为空的值类型，零值为 None；替代用 *T 表示"可能没有"
+type Option[T any] struct {
+	v  T
+	ok bool
+}
+
+func Some[T any](v T) Option[T] {
+	return Option[T]{v: v, ok: true}
+}
+
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// FromPtr nil 为 None，否则为指向值的拷贝
+func FromPtr[T any](p *T) Option[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// Ptr None 返回 nil，否则返回值拷贝的指针
+func (o Option[T]) Ptr() *T {
+	if !o.ok {
+		return nil
+	}
+	v := o.v
+	return &v
+}
+
+func (o Option[T]) Get() (T, bool) {
+	return o.v, o.ok
+}
+
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+// IsZero 供 json 的 omitzero 使用
+func (o Option[T]) IsZero() bool {
+	return !o.ok
+}
+
+func (o Option[T]) OrElse(def T) T {
+	if !o.ok {
+		return def
+	}
+	return o.v
+}
+
+// OrElseGet 只有 None 时才调用 fn
+func (o Option[T]) OrElseGet(fn func() T) T {
+	if !o.ok {
+		return fn()
+	}
+	return o.v
+}
+
+// Filter 有值但不满足 pred 时返回 None
+func (o Option[T]) Filter(pred func(T) bool) Option[T] {
+	if !o.ok || !pred(o.v) {
+		return None[T]()
+	}
+	return o
+}
+
+func Map[T, R any](o Option[T], fn func(T) R) Option[R] {
+	if !o.ok {
+		return None[R]()
+	}
+	return Some(fn(o.v))
+}
+
+func FlatMap[T, R any](o Option[T], fn func(T) Option[R]) Option[R] {
+	if !o.ok {
+		return None[R]()
+	}
+	return fn(o.v)
+}
+
+// MarshalJSON None 编码为 null
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if !o.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.v)
+}
+
+// UnmarshalJSON null 解码为 None；字段缺失时保持零值，同样为 None
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = None[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// Scan 实现 sql.Scanner，NULL 为 None
+func (o *Option[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = Option[T]{v: n.V, ok: n.Valid}
+	return nil
+}
+
+// Value 实现 driver.Valuer，None 写入 NULL；有值时转换为 driver.Value 支持的类型（如 int 转为 int64）
+func (o Option[T]) Value() (driver.Value, error) {
+	if !o.ok {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.v)
+}
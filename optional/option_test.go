@@ -0,0 +1,180 @@
+package optional
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOption(t *testing.T) {
+	five := 5
+	tests := []struct {
+		name     string
+		o        Option[int]
+		wantV    int
+		wantOk   bool
+		orElse   int
+		filtered bool
+	}{
+		{"some", Some(5), 5, true, 5, true},
+		{"none", None[int](), 0, false, -1, false},
+		{"zero value", Option[int]{}, 0, false, -1, false},
+		{"from ptr", FromPtr(&five), 5, true, 5, true},
+		{"from nil", FromPtr[int](nil), 0, false, -1, false},
+		{"filtered out", Some(4), 4, true, 4, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := tt.o.Get()
+			if v != tt.wantV || ok != tt.wantOk || tt.o.IsSome() != ok || tt.o.IsNone() == ok {
+				t.Errorf("Get() = %v, %v, want %v, %v", v, ok, tt.wantV, tt.wantOk)
+			}
+			if got := tt.o.OrElse(-1); got != tt.orElse {
+				t.Errorf("OrElse() = %v, want %v", got, tt.orElse)
+			}
+			if got := tt.o.OrElseGet(func() int { return -1 }); got != tt.orElse {
+				t.Errorf("OrElseGet() = %v, want %v", got, tt.orElse)
+			}
+			if got := tt.o.Filter(func(v int) bool { return v%2 == 1 }).IsSome(); got != tt.filtered {
+				t.Errorf("Filter() = %v, want %v", got, tt.filtered)
+			}
+			if p := tt.o.Ptr(); (p != nil) != ok || (p != nil && *p != v) {
+				t.Errorf("Ptr() = %v", p)
+			}
+		})
+	}
+}
+
+func TestOption_Ptr(t *testing.T) {
+	v := 1
+	o := FromPtr(&v)
+	v = 2
+	p := o.Ptr()
+	*p = 3
+	if got := o.OrElse(0); got != 1 {
+		t.Errorf("Option shares memory with pointers, got %v", got)
+	}
+}
+
+func TestMap(t *testing.T) {
+	if got := Map(Some(2), strconv.Itoa); got != Some("2") {
+		t.Errorf("Map(Some) = %v", got)
+	}
+	if got := Map(None[int](), strconv.Itoa); got.IsSome() {
+		t.Errorf("Map(None) = %v", got)
+	}
+
+	parse := func(s string) Option[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return None[int]()
+		}
+		return Some(n)
+	}
+	tests := []struct {
+		in   Option[string]
+		want Option[int]
+	}{
+		{Some("12"), Some(12)},
+		{Some("x"), None[int]()},
+		{None[string](), None[int]()},
+	}
+	for _, tt := range tests {
+		if got := FlatMap(tt.in, parse); got != tt.want {
+			t.Errorf("FlatMap(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOption_JSON(t *testing.T) {
+	type user struct {
+		Name Option[string] `json:"name"`
+		Age  Option[int]    `json:"age"`
+	}
+	tests := []struct {
+		in   string
+		want user
+		out  string
+	}{
+		{`{"name":"a","age":3}`, user{Some("a"), Some(3)}, `{"name":"a","age":3}`},
+		{`{"name":null,"age":0}`, user{None[string](), Some(0)}, `{"name":null,"age":0}`},
+		{`{}`, user{}, `{"name":null,"age":null}`},
+	}
+	for _, tt := range tests {
+		var got user
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		out, err := json.Marshal(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tt.out {
+			t.Errorf("Marshal = %s, want %s", out, tt.out)
+		}
+	}
+
+	var o Option[int]
+	if err := json.Unmarshal([]byte(`"x"`), &o); err == nil {
+		t.Error("Unmarshal wrong type, want error")
+	}
+}
+
+func TestOption_SQL(t *testing.T) {
+	tests := []struct {
+		src  any
+		want Option[int64]
+	}{
+		{nil, None[int64]()},
+		{int64(7), Some[int64](7)},
+		{[]byte("8"), Some[int64](8)},
+	}
+	for _, tt := range tests {
+		o := Some[int64](-1)
+		if err := o.Scan(tt.src); err != nil {
+			t.Fatal(err)
+		}
+		if o != tt.want {
+			t.Errorf("Scan(%v) = %v, want %v", tt.src, o, tt.want)
+		}
+	}
+	var s Option[string]
+	if err := s.Scan(int64(3)); err != nil || s != Some("3") {
+		t.Errorf("Scan into string = %v, %v", s, err)
+	}
+
+	if v, err := None[int64]().Value(); err != nil || v != nil {
+		t.Errorf("None.Value() = %v, %v", v, err)
+	}
+	values := []struct {
+		o    driver.Valuer
+		want driver.Value
+	}{
+		{Some[int64](3), int64(3)},
+		{Some(3), int64(3)},
+		{Some[int32](4), int64(4)},
+		{Some[uint8](5), int64(5)},
+		{Some(1.5), 1.5},
+		{Some("s"), "s"},
+		{Some(true), true},
+	}
+	for _, tt := range values {
+		v, err := tt.o.Value()
+		if err != nil || v != tt.want {
+			t.Errorf("%v.Value() = %#v, %v, want %#v", tt.o, v, err, tt.want)
+		}
+		if !driver.IsValue(v) {
+			t.Errorf("%v.Value() = %T, not a driver.Value", tt.o, v)
+		}
+	}
+	now := time.Unix(100, 0)
+	if v, err := Some(now).Value(); err != nil || !reflect.DeepEqual(v, now) {
+		t.Errorf("Some(time).Value() = %v, %v", v, err)
+	}
+}
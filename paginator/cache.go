@@ -0,0 +1,215 @@
+package paginator
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"sync"
+)
+
+// ChunkCache 多个数据源共享的分段缓存，按 (数据源, 区间) 做 LRU，capacity 为缓存的元素总数上限
+type ChunkCache[T any] struct {
+	capacity int
+
+	mu    sync.Mutex
+	lru   *list.List                 // *cacheSeg[T]，队头最近使用
+	segs  map[string][]*list.Element // 按数据源名分组
+	size  int
+	stats CacheStats
+}
+
+type cacheSeg[T any] struct {
+	src    string
+	offset int
+	items  []T
+}
+
+func (s *cacheSeg[T]) end() int {
+	return s.offset + len(s.items)
+}
+
+// CacheStats 按请求和元素分别统计命中
+type CacheStats struct {
+	Hits      int64 // 完全由缓存满足的请求数
+	Misses    int64 // 需要回源的请求数
+	HitItems  int64
+	MissItems int64
+	Evictions int64
+	Items     int // 当前缓存的元素数
+}
+
+// cachedSource 装饰后的数据源，Total 不缓存
+type cachedSource[T any] struct {
+	name  string
+	src   DataSourceCtx[T]
+	cache *ChunkCache[T]
+}
+
+// piece 一次请求拆出的连续片段，items 为空表示需要回源
+type piece[T any] struct {
+	offset int
+	limit  int
+	items  []T
+	cached bool
+}
+
+func NewChunkCache[T any](capacity int) *ChunkCache[T] {
+	return &ChunkCache[T]{
+		capacity: capacity,
+		lru:      list.New(),
+		segs:     make(map[string][]*list.Element),
+	}
+}
+
+// Wrap 用 name 标识数据源，同一缓存内 name 需唯一
+func (c *ChunkCache[T]) Wrap(name string, src DataSourceCtx[T]) DataSourceCtx[T] {
+	return &cachedSource[T]{name: name, src: src, cache: c}
+}
+
+// Invalidate 丢弃某个数据源的全部缓存
+func (c *ChunkCache[T]) Invalidate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range c.segs[name] {
+		c.size -= len(c.lru.Remove(e).(*cacheSeg[T]).items)
+	}
+	delete(c.segs, name)
+}
+
+func (c *ChunkCache[T]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Items = c.size
+	return stats
+}
+
+func (s *cachedSource[T]) Total(ctx context.Context) (int, error) {
+	return s.src.Total(ctx)
+}
+
+// GetChunk 命中的片段直接使用，只拉取缺口
+func (s *cachedSource[T]) GetChunk(ctx context.Context, offset int, limit int) ([]T, error) {
+	pieces := s.cache.lookup(s.name, offset, limit)
+
+	result := make([]T, 0, limit)
+	for i := range pieces {
+		pc := &pieces[i]
+		if !pc.cached {
+			chunk, err := s.src.GetChunk(ctx, pc.offset, pc.limit)
+			if err != nil {
+				return nil, err
+			}
+			pc.items = chunk
+			s.cache.store(s.name, pc.offset, chunk)
+		}
+		result = append(result, pc.items...)
+		if len(pc.items) < pc.limit {
+			break // 数据源已到末尾
+		}
+	}
+	s.cache.record(pieces)
+	return result, nil
+}
+
+func (c *ChunkCache[T]) lookup(name string, offset, limit int) []piece[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		pieces []piece[T]
+		pos    = offset
+		end    = offset + limit
+		segs   = c.segs[name]
+	)
+	for pos < end {
+		var (
+			cover   *list.Element
+			nextOff = end
+		)
+		for _, e := range segs {
+			seg := e.Value.(*cacheSeg[T])
+			if seg.offset <= pos && seg.end() > pos {
+				if cover == nil || seg.end() > cover.Value.(*cacheSeg[T]).end() {
+					cover = e
+				}
+			} else if seg.offset > pos && seg.offset < nextOff {
+				nextOff = seg.offset
+			}
+		}
+		if cover == nil {
+			pieces = append(pieces, piece[T]{offset: pos, limit: nextOff - pos})
+			pos = nextOff
+			continue
+		}
+		c.lru.MoveToFront(cover)
+		seg := cover.Value.(*cacheSeg[T])
+		take := min(seg.end(), end) - pos
+		pieces = append(pieces, piece[T]{
+			offset: pos,
+			limit:  take,
+			items:  seg.items[pos-seg.offset : pos-seg.offset+take],
+			cached: true,
+		})
+		pos += take
+	}
+	return pieces
+}
+
+func (c *ChunkCache[T]) store(name string, offset int, items []T) {
+	if len(items) == 0 || len(items) > c.capacity {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.lru.PushFront(&cacheSeg[T]{src: name, offset: offset, items: append([]T(nil), items...)})
+	segs := append(c.segs[name], e)
+	sort.Slice(segs, func(i, j int) bool {
+		return segs[i].Value.(*cacheSeg[T]).offset < segs[j].Value.(*cacheSeg[T]).offset
+	})
+	c.segs[name] = segs
+	c.size += len(items)
+
+	for c.size > c.capacity {
+		c.evict(c.lru.Back())
+	}
+}
+
+func (c *ChunkCache[T]) evict(e *list.Element) {
+	seg := c.lru.Remove(e).(*cacheSeg[T])
+	c.size -= len(seg.items)
+	c.stats.Evictions++
+
+	segs := c.segs[seg.src]
+	for i, se := range segs {
+		if se == e {
+			segs = append(segs[:i], segs[i+1:]...)
+			break
+		}
+	}
+	if len(segs) == 0 {
+		delete(c.segs, seg.src)
+		return
+	}
+	c.segs[seg.src] = segs
+}
+
+func (c *ChunkCache[T]) record(pieces []piece[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	miss := false
+	for _, pc := range pieces {
+		if pc.cached {
+			c.stats.HitItems += int64(len(pc.items))
+		} else {
+			miss = true
+			c.stats.MissItems += int64(len(pc.items))
+		}
+	}
+	if miss {
+		c.stats.Misses++
+	} else {
+		c.stats.Hits++
+	}
+}
@@ -0,0 +1,90 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type recordSource struct {
+	ctxSource
+	calls [][2]int
+}
+
+func (s *recordSource) GetChunk(ctx context.Context, offset, limit int) ([]int, error) {
+	s.calls = append(s.calls, [2]int{offset, limit})
+	return s.ctxSource.GetChunk(ctx, offset, limit)
+}
+
+func TestChunkCache(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		raw   = &recordSource{ctxSource: ctxSource{total: 20}}
+		cache = NewChunkCache[int](8)
+		src   = cache.Wrap("a", raw)
+	)
+	read := func(offset, limit int) []int {
+		t.Helper()
+		got, err := src.GetChunk(ctx, offset, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	read(2, 3) // [2,5)
+	read(7, 2) // [7,9)
+	raw.calls = nil
+
+	// [0,10) 只回源缺口 [0,2) [5,7) [9,10)
+	if got, want := read(0, 10), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetChunk(0, 10) = %v", got)
+	}
+	if want := [][2]int{{0, 2}, {5, 2}, {9, 1}}; !reflect.DeepEqual(raw.calls, want) {
+		t.Errorf("source calls = %v, want %v", raw.calls, want)
+	}
+
+	// 容量 8，最早的 [2,5) 已被淘汰
+	stats := cache.Stats()
+	if stats.Items > 8 || stats.Evictions == 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	raw.calls = nil
+	if got := read(5, 2); !reflect.DeepEqual(got, []int{5, 6}) || len(raw.calls) != 0 {
+		t.Errorf("GetChunk(5, 2) = %v, calls = %v", got, raw.calls)
+	}
+	// 末尾短块
+	if got := read(18, 5); !reflect.DeepEqual(got, []int{18, 19}) {
+		t.Errorf("GetChunk(18, 5) = %v", got)
+	}
+
+	stats = cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 4 || stats.HitItems != 7 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	cache.Invalidate("a")
+	raw.calls = nil
+	read(5, 2)
+	if len(raw.calls) != 1 || cache.Stats().Items != 2 {
+		t.Errorf("after Invalidate calls = %v, stats = %+v", raw.calls, cache.Stats())
+	}
+}
+
+func TestChunkCache_Paginator(t *testing.T) {
+	var (
+		cache = NewChunkCache[int](100)
+		raw1  = &recordSource{ctxSource: ctxSource{total: 5}}
+		raw2  = &recordSource{ctxSource: ctxSource{total: 5}}
+		p     = NewPaginatorCtx([]DataSourceCtx[int]{cache.Wrap("1", raw1), cache.Wrap("2", raw2)}, 3)
+	)
+	for _, n := range []int{0, 1, 2, 1, 0} {
+		if _, err := p.GetPage(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(raw1.calls) != 2 || len(raw2.calls) != 2 {
+		t.Errorf("source calls = %v / %v", raw1.calls, raw2.calls)
+	}
+}
@@ -0,0 +1,57 @@
+package paginator
+
+import (
+	"fmt"
+
+	"github.com/1298509345/go-utils-frequently/ds/bloom"
+	"github.com/1298509345/go-utils-frequently/ds/slice"
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+// DedupPaginator 跨数据源去重分页：按数据源顺序，只保留每个ID第一次出现的元素，页仍填满 pageSize
+type DedupPaginator[T any, ID comparable] struct {
+	*FilterPaginator[T]
+	identifier slice.Identifier[T, ID]
+
+	firstSeen map[ID]int // 精确模式：ID 第一次出现的原始位置
+	bloom     *bloom.Filter
+}
+
+// NewDedupPaginator 默认精确去重，内存随不同ID数增长；超大扫描可用 WithBloom
+func NewDedupPaginator[T any, ID comparable](p *Paginator[T], identifier slice.Identifier[T, ID], options ...optional.Op[DedupPaginator[T, ID]]) *DedupPaginator[T, ID] {
+	d := &DedupPaginator[T, ID]{identifier: identifier}
+	for _, option := range options {
+		option(d)
+	}
+
+	if d.bloom == nil {
+		d.firstSeen = make(map[ID]int)
+		d.FilterPaginator = newFilterPaginator(p, d.matchExact)
+		return d
+	}
+	d.FilterPaginator = newFilterPaginator(p, d.matchBloom)
+	d.forwardOnly = true
+	d.onReset = d.bloom.Reset
+	return d
+}
+
+// WithBloom 用布隆过滤器代替精确集合，内存固定；误判会多跳过少量不重复元素，回看已翻过的页时需从头重扫
+func WithBloom[T any, ID comparable](expectedItems int, fpRate float64) optional.Op[DedupPaginator[T, ID]] {
+	return func(d *DedupPaginator[T, ID]) {
+		d.bloom = bloom.New(expectedItems, fpRate)
+	}
+}
+
+// matchExact 原始位置即第一次出现位置时保留，重扫同一区间结果不变
+func (d *DedupPaginator[T, ID]) matchExact(raw int, item T) bool {
+	id := d.identifier(item)
+	if first, ok := d.firstSeen[id]; ok {
+		return first == raw
+	}
+	d.firstSeen[id] = raw
+	return true
+}
+
+func (d *DedupPaginator[T, ID]) matchBloom(_ int, item T) bool {
+	return !d.bloom.TestAndAdd(fmt.Append(nil, d.identifier(item)))
+}
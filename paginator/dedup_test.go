@@ -0,0 +1,62 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1298509345/go-utils-frequently/ds/slice"
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+func TestDedupPaginator(t *testing.T) {
+	var (
+		cache   = &SliceSource[int]{data: []int{3, 1, 5}}
+		primary = &SliceSource[int]{data: []int{1, 2, 3, 4, 5, 6, 7, 8}}
+		want    = []int{3, 1, 5, 2, 4, 6, 7, 8}
+	)
+
+	type testCase struct {
+		name    string
+		options []optional.Op[DedupPaginator[int, int]]
+	}
+	tests := []testCase{
+		{name: "exact"},
+		{name: "bloom", options: []optional.Op[DedupPaginator[int, int]]{WithBloom[int, int](1000, 0.001)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPaginator([]DataSource[int]{cache, primary}, 3)
+			d := NewDedupPaginator(p, slice.IdentifierSelf[int], tt.options...)
+
+			var all []int
+			for n := 0; ; n++ {
+				page, err := d.GetPage(context.Background(), n)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(page) == 0 {
+					break
+				}
+				if len(page) < 3 && n < 2 {
+					t.Errorf("page %d not filled: %v", n, page)
+				}
+				all = append(all, page...)
+			}
+			if !reflect.DeepEqual(all, want) {
+				t.Errorf("pages = %v, want %v", all, want)
+			}
+
+			// 回看
+			if page, _ := d.GetPage(context.Background(), 1); !reflect.DeepEqual(page, []int{2, 4, 6}) {
+				t.Errorf("page 1 = %v", page)
+			}
+			if page, _ := d.GetPage(context.Background(), 2); !reflect.DeepEqual(page, []int{7, 8}) {
+				t.Errorf("page 2 = %v", page)
+			}
+			if total, exact, _ := d.ApproxTotal(context.Background()); !exact || total != len(want) {
+				t.Errorf("ApproxTotal() = %v, %v", total, exact)
+			}
+		})
+	}
+}
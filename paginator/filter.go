@@ -0,0 +1,131 @@
+package paginator
+
+import (
+	"context"
+	"sync"
+)
+
+// FilterPaginator 数据源不支持服务端过滤时，按块拉取原始数据并用 pred 过滤直到填满一页；
+// 记录每页在原始数据中的起点，翻回已扫描过的页不会从头扫描
+type FilterPaginator[T any] struct {
+	p     *Paginator[T]
+	match func(raw int, item T) bool // raw 为元素在拼接后原始数据中的位置
+
+	forwardOnly bool   // match 有状态且只能顺序调用，回看已扫描区间时需从头重扫
+	onReset     func() // forwardOnly 重扫前清理 match 的状态
+
+	mu        sync.Mutex
+	starts    []int // starts[i] 第 i 页（从0计）第一个候选元素的原始位置
+	scanned   int   // 已连续扫描过的原始元素数
+	matched   int   // 已扫描元素中满足 pred 的个数
+	exhausted bool  // 已扫描到原始数据末尾
+}
+
+// NewFilterPaginator 页大小、页码约定与 p 一致
+func NewFilterPaginator[T any](p *Paginator[T], pred func(T) bool) *FilterPaginator[T] {
+	return newFilterPaginator(p, func(_ int, item T) bool { return pred(item) })
+}
+
+func newFilterPaginator[T any](p *Paginator[T], match func(raw int, item T) bool) *FilterPaginator[T] {
+	return &FilterPaginator[T]{p: p, match: match, starts: []int{0}}
+}
+
+func (f *FilterPaginator[T]) GetPage(ctx context.Context, pageNum int) ([]T, error) {
+	idx := pageNum - f.p.pageBase
+	if idx < 0 {
+		return nil, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	known := min(idx, len(f.starts)-1)
+	if f.forwardOnly && f.starts[known] < f.scanned {
+		f.reset()
+		known = 0
+	}
+	for ; ; known++ {
+		items, next, err := f.scan(ctx, f.starts[known])
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == f.p.pageSize && known+1 == len(f.starts) {
+			f.starts = append(f.starts, next)
+		}
+		if known == idx {
+			return items, nil
+		}
+		if len(items) < f.p.pageSize {
+			return []T{}, nil
+		}
+	}
+}
+
+// scan 从原始位置 raw 开始收集一页，返回下一页的原始起点
+func (f *FilterPaginator[T]) scan(ctx context.Context, raw int) ([]T, int, error) {
+	var (
+		pageSize = f.p.pageSize
+		result   = make([]T, 0, pageSize)
+	)
+	for len(result) < pageSize {
+		chunk, err := f.p.GetRangeCtx(ctx, raw, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(chunk) == 0 {
+			f.exhausted = true
+			break
+		}
+		for _, item := range chunk {
+			match := f.match(raw, item)
+			if raw == f.scanned {
+				f.scanned++
+				if match {
+					f.matched++
+				}
+			}
+			raw++
+			if match {
+				result = append(result, item)
+				if len(result) == pageSize {
+					break
+				}
+			}
+		}
+	}
+	return result, raw, nil
+}
+
+func (f *FilterPaginator[T]) reset() {
+	f.starts = f.starts[:1]
+	f.scanned, f.matched, f.exhausted = 0, 0, false
+	if f.onReset != nil {
+		f.onReset()
+	}
+}
+
+// ApproxTotal 按已扫描部分的命中率估算过滤后的总数，扫描到末尾后 exact 为 true
+func (f *FilterPaginator[T]) ApproxTotal(ctx context.Context) (total int, exact bool, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.exhausted {
+		return f.matched, true, nil
+	}
+	if f.scanned == 0 {
+		return 0, false, nil
+	}
+
+	totals, err := f.p.getTotals(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	// 不额外探测未知总数的数据源，按已知下界估算
+	if totals, _, err = f.p.resolveTotals(ctx, totals, 0); err != nil {
+		return 0, false, err
+	}
+	var rawTotal int
+	for _, t := range totals {
+		rawTotal += t
+	}
+	return f.matched * rawTotal / f.scanned, false, nil
+}
@@ -0,0 +1,82 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+type chunkCounter[T any] struct {
+	SliceSource[T]
+	chunks atomic.Int32
+}
+
+func (c *chunkCounter[T]) GetChunk(offset, limit int) ([]T, error) {
+	c.chunks.Add(1)
+	return c.SliceSource.GetChunk(offset, limit)
+}
+
+func TestFilterPaginator(t *testing.T) {
+	var (
+		evens []int
+		src1  = &chunkCounter[int]{SliceSource: SliceSource[int]{}}
+		src2  = &chunkCounter[int]{SliceSource: SliceSource[int]{}}
+	)
+	for i := 1; i <= 20; i++ {
+		src1.data = append(src1.data, i)
+		src2.data = append(src2.data, i+20)
+	}
+	for i := 2; i <= 40; i += 2 {
+		evens = append(evens, i)
+	}
+
+	f := NewFilterPaginator(
+		NewPaginator([]DataSource[int]{src1, src2}, 3, WithPageBase[int](1)),
+		func(i int) bool { return i%2 == 0 },
+	)
+
+	var all []int
+	for n := 1; ; n++ {
+		page, err := f.GetPage(context.Background(), n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		all = append(all, page...)
+
+		if n == 2 {
+			total, exact, _ := f.ApproxTotal(context.Background())
+			if exact || total != 20 {
+				t.Errorf("ApproxTotal() = %v, %v, want 20, false", total, exact)
+			}
+		}
+	}
+	if !reflect.DeepEqual(all, evens) {
+		t.Errorf("pages = %v, want %v", all, evens)
+	}
+	if total, exact, _ := f.ApproxTotal(context.Background()); !exact || total != 20 {
+		t.Errorf("ApproxTotal() = %v, %v, want 20, true", total, exact)
+	}
+
+	// 回到已扫描的页只扫描该页对应的原始区间
+	before := src1.chunks.Load() + src2.chunks.Load()
+	page, _ := f.GetPage(context.Background(), 3)
+	if !reflect.DeepEqual(page, []int{14, 16, 18}) {
+		t.Errorf("page 3 = %v", page)
+	}
+	if cost := src1.chunks.Load() + src2.chunks.Load() - before; cost > 2 {
+		t.Errorf("revisiting page 3 fetched %d chunks", cost)
+	}
+
+	// 跳页
+	jump := NewFilterPaginator(NewPaginator([]DataSource[int]{src1, src2}, 3, WithPageBase[int](1)), func(i int) bool { return i%2 == 0 })
+	if page, _ := jump.GetPage(context.Background(), 7); !reflect.DeepEqual(page, []int{38, 40}) {
+		t.Errorf("page 7 = %v", page)
+	}
+	if page, _ := jump.GetPage(context.Background(), 9); len(page) != 0 {
+		t.Errorf("page 9 = %v", page)
+	}
+}
@@ -0,0 +1,198 @@
+package httppage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/1298509345/go-utils-frequently/optional"
+	"github.com/1298509345/go-utils-frequently/paginator"
+)
+
+const (
+	defaultPageSize = 20
+	defaultMaxSize  = 100
+)
+
+// Config 查询参数名和页大小限制，页码从1开始
+type Config struct {
+	PageParam       string
+	PageSizeParam   string
+	TokenParam      string // 携带该参数（值可为空，表示第一页）时使用令牌分页，需 Paginator 配置 WithTokenKey；令牌分页始终按正序
+	DefaultPageSize int
+	MaxPageSize     int // 超出时按 MaxPageSize 处理
+
+	// ErrorHandler 可选，以原始错误调用，用于记录日志：返回 500 前，或 partial 模式下跳过了失败的数据源时；响应体只包含通用错误信息
+	ErrorHandler func(r *http.Request, err error)
+}
+
+// Envelope 响应体
+type Envelope[T any] struct {
+	Items          []T                 `json:"items"`
+	PageInfo       *paginator.PageInfo `json:"page_info,omitempty"`
+	NextToken      string              `json:"next_token,omitempty"`
+	SkippedSources []string            `json:"skipped_sources,omitempty"` // WithPartialResults 模式下本页跳过的数据源名
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func WithPageParams(page, pageSize, token string) optional.Op[Config] {
+	return func(c *Config) {
+		c.PageParam, c.PageSizeParam, c.TokenParam = page, pageSize, token
+	}
+}
+
+func WithPageSize(def, max int) optional.Op[Config] {
+	return func(c *Config) {
+		c.DefaultPageSize, c.MaxPageSize = def, max
+	}
+}
+
+func WithErrorHandler(fn func(r *http.Request, err error)) optional.Op[Config] {
+	return func(c *Config) {
+		c.ErrorHandler = fn
+	}
+}
+
+// Handler 解析分页参数，按 Paginator 的分页逻辑（倒序、快照、未知总数等）取页，返回 JSON 并按 RFC 8288 设置 first/prev/next/last 的 Link 头
+func Handler[T any](p *paginator.Paginator[T], options ...optional.Op[Config]) http.Handler {
+	cfg := optional.New(&Config{
+		PageParam:       "page",
+		PageSizeParam:   "page_size",
+		TokenParam:      "token",
+		DefaultPageSize: defaultPageSize,
+		MaxPageSize:     defaultMaxSize,
+	}, options...)
+	if cfg.MaxPageSize <= 0 {
+		cfg.MaxPageSize = defaultMaxSize
+	}
+	if cfg.DefaultPageSize <= 0 || cfg.DefaultPageSize > cfg.MaxPageSize {
+		cfg.DefaultPageSize = min(defaultPageSize, cfg.MaxPageSize)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if cfg.TokenParam != "" && query.Has(cfg.TokenParam) {
+			serveToken(w, r, p, cfg)
+			return
+		}
+		servePage(w, r, p, cfg)
+	})
+}
+
+func servePage[T any](w http.ResponseWriter, r *http.Request, p *paginator.Paginator[T], cfg *Config) {
+	page, err := intParam(r.URL.Query(), cfg.PageParam, 1)
+	if err != nil || page < 1 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s", cfg.PageParam))
+		return
+	}
+	size, err := intParam(r.URL.Query(), cfg.PageSizeParam, cfg.DefaultPageSize)
+	if err != nil || size < 1 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s", cfg.PageSizeParam))
+		return
+	}
+	size = min(size, cfg.MaxPageSize)
+
+	items, info, err := p.GetPageSizedCtx(r.Context(), page-1+p.PageBase(), size)
+	var srcErrs paginator.SourceErrors
+	if err != nil && !errors.As(err, &srcErrs) {
+		internalError(w, r, cfg, err)
+		return
+	}
+	if err != nil && cfg.ErrorHandler != nil {
+		cfg.ErrorHandler(r, err) // 部分数据源失败只作为警告，仍返回其余数据源的数据
+	}
+	info.Page = page // 对外页码固定从1开始
+
+	var links []string
+	link := func(rel string, n int) {
+		q := r.URL.Query()
+		q.Set(cfg.PageParam, strconv.Itoa(n))
+		q.Set(cfg.PageSizeParam, strconv.Itoa(size))
+		links = append(links, formatLink(r, q, rel))
+	}
+	link("first", 1)
+	if info.HasPrev {
+		link("prev", page-1)
+	}
+	if info.HasNext {
+		link("next", page+1)
+	}
+	if !info.Estimated {
+		link("last", max(info.TotalPages, 1)) // 总数未知时没有最后一页
+	}
+	w.Header().Set("Link", strings.Join(links, ", "))
+
+	writeJSON(w, http.StatusOK, Envelope[T]{Items: items, PageInfo: &info, SkippedSources: srcErrs.Names()})
+}
+
+func serveToken[T any](w http.ResponseWriter, r *http.Request, p *paginator.Paginator[T], cfg *Config) {
+	var (
+		items []T
+		next  string
+		err   error
+		token = r.URL.Query().Get(cfg.TokenParam)
+	)
+	if token == "" {
+		items, next, err = p.FirstPageCtx(r.Context())
+	} else {
+		items, next, err = p.NextPageCtx(r.Context(), token)
+	}
+	switch {
+	case errors.Is(err, paginator.ErrInvalidToken), errors.Is(err, paginator.ErrTokenExpired):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		internalError(w, r, cfg, err)
+		return
+	}
+
+	q := r.URL.Query()
+	q.Set(cfg.TokenParam, "")
+	links := []string{formatLink(r, q, "first")}
+	if next != "" {
+		q.Set(cfg.TokenParam, next)
+		links = append(links, formatLink(r, q, "next"))
+	}
+	w.Header().Set("Link", strings.Join(links, ", "))
+
+	writeJSON(w, http.StatusOK, Envelope[T]{Items: items, NextToken: next})
+}
+
+func intParam(query url.Values, name string, def int) (int, error) {
+	v := query.Get(name)
+	if v == "" {
+		return def, nil
+	}
+	return strconv.Atoi(v)
+}
+
+func formatLink(r *http.Request, query url.Values, rel string) string {
+	u := *r.URL
+	u.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// internalError 不把内部错误写入响应，交给 ErrorHandler 处理
+func internalError(w http.ResponseWriter, r *http.Request, cfg *Config, err error) {
+	if cfg.ErrorHandler != nil {
+		cfg.ErrorHandler(r, err)
+	}
+	writeError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorBody{Error: msg})
+}
@@ -0,0 +1,222 @@
+package httppage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1298509345/go-utils-frequently/paginator"
+)
+
+func newTestPaginator() *paginator.Paginator[int] {
+	return paginator.NewPaginator([]paginator.DataSource[int]{
+		paginator.NewSliceSource([]int{1, 2, 3}),
+		paginator.NewSliceSource([]int{4, 5, 6, 7, 8, 9, 10}),
+	}, 5, paginator.WithTokenKey[int]([]byte("k"), 0))
+}
+
+func get(t *testing.T, h http.Handler, target string) (*httptest.ResponseRecorder, Envelope[int]) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	var env Envelope[int]
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rec, env
+}
+
+func TestHandler_Page(t *testing.T) {
+	h := Handler(newTestPaginator(), WithPageSize(3, 4))
+
+	rec, env := get(t, h, "/items?page=2&filter=x")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %v, body = %s", rec.Code, rec.Body)
+	}
+	if !reflect.DeepEqual(env.Items, []int{4, 5, 6}) {
+		t.Errorf("items = %v", env.Items)
+	}
+	want := paginator.PageInfo{Page: 2, PageSize: 3, TotalItems: 10, TotalPages: 4, HasNext: true, HasPrev: true}
+	if *env.PageInfo != want {
+		t.Errorf("page_info = %+v, want %+v", *env.PageInfo, want)
+	}
+	link := rec.Header().Get("Link")
+	for _, part := range []string{
+		`</items?filter=x&page=1&page_size=3>; rel="first"`,
+		`</items?filter=x&page=1&page_size=3>; rel="prev"`,
+		`</items?filter=x&page=3&page_size=3>; rel="next"`,
+		`</items?filter=x&page=4&page_size=3>; rel="last"`,
+	} {
+		if !strings.Contains(link, part) {
+			t.Errorf("Link = %s, missing %s", link, part)
+		}
+	}
+
+	// 超出上限按上限处理
+	_, env = get(t, h, "/items?page=1&page_size=50")
+	if env.PageInfo.PageSize != 4 || len(env.Items) != 4 {
+		t.Errorf("clamped page = %+v", env)
+	}
+
+	rec, env = get(t, h, "/items?page=4")
+	if strings.Contains(rec.Header().Get("Link"), `rel="next"`) || !reflect.DeepEqual(env.Items, []int{10}) {
+		t.Errorf("last page link = %s, items = %v", rec.Header().Get("Link"), env.Items)
+	}
+
+	for _, bad := range []string{"/items?page=0", "/items?page=x", "/items?page_size=-1"} {
+		if rec, _ := get(t, h, bad); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s status = %v", bad, rec.Code)
+		}
+	}
+}
+
+func TestHandler_Token(t *testing.T) {
+	h := Handler(newTestPaginator())
+
+	var (
+		all   []int
+		token = ""
+	)
+	for i := 0; i < 5; i++ {
+		rec, env := get(t, h, "/items?token="+token)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %v, body = %s", rec.Code, rec.Body)
+		}
+		all = append(all, env.Items...)
+		if env.NextToken == "" {
+			if strings.Contains(rec.Header().Get("Link"), `rel="next"`) {
+				t.Errorf("unexpected next link on last page")
+			}
+			break
+		}
+		if !strings.Contains(rec.Header().Get("Link"), `rel="next"`) {
+			t.Errorf("missing next link: %s", rec.Header().Get("Link"))
+		}
+		token = env.NextToken
+	}
+	if !reflect.DeepEqual(all, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+		t.Errorf("items = %v", all)
+	}
+
+	if rec, _ := get(t, h, "/items?token=forged.token"); rec.Code != http.StatusBadRequest {
+		t.Errorf("forged token status = %v", rec.Code)
+	}
+}
+
+// unknownSource 不提供总数的数据源
+type unknownSource struct {
+	*paginator.SliceSource[int]
+}
+
+func (s unknownSource) Total() int {
+	return paginator.UnknownTotal
+}
+
+type failingSource struct{}
+
+func (failingSource) GetChunk(context.Context, int, int) ([]int, error) {
+	return nil, errors.New("dial tcp 10.0.0.7:5432: connection refused")
+}
+
+func (failingSource) Total(context.Context) (int, error) {
+	return 0, errors.New("dial tcp 10.0.0.7:5432: connection refused")
+}
+
+func TestHandler_PaginatorModes(t *testing.T) {
+	t.Run("reverse", func(t *testing.T) {
+		p := paginator.NewPaginator([]paginator.DataSource[int]{
+			paginator.NewSliceSource([]int{1, 2, 3, 4, 5}),
+		}, 2, paginator.WithReverse[int]())
+		_, env := get(t, Handler(p), "/items?page=1&page_size=3")
+		if !reflect.DeepEqual(env.Items, []int{5, 4, 3}) || env.PageInfo.Page != 1 || !env.PageInfo.HasNext {
+			t.Errorf("reverse page = %v, %+v", env.Items, env.PageInfo)
+		}
+	})
+
+	t.Run("page base 1", func(t *testing.T) {
+		p := paginator.NewPaginator([]paginator.DataSource[int]{
+			paginator.NewSliceSource([]int{1, 2, 3, 4, 5}),
+		}, 2, paginator.WithPageBase[int](1))
+		_, env := get(t, Handler(p), "/items?page=2&page_size=2")
+		if !reflect.DeepEqual(env.Items, []int{3, 4}) || env.PageInfo.Page != 2 {
+			t.Errorf("page = %v, %+v", env.Items, env.PageInfo)
+		}
+	})
+
+	t.Run("estimated", func(t *testing.T) {
+		p := paginator.NewPaginator([]paginator.DataSource[int]{
+			unknownSource{paginator.NewSliceSource([]int{1, 2, 3, 4, 5, 6, 7})},
+		}, 2)
+		rec, env := get(t, Handler(p), "/items?page=1&page_size=3")
+		if !env.PageInfo.Estimated || !env.PageInfo.HasNext || strings.Contains(rec.Header().Get("Link"), `rel="last"`) {
+			t.Errorf("page_info = %+v, Link = %s", env.PageInfo, rec.Header().Get("Link"))
+		}
+	})
+
+	t.Run("snapshot drift", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		src := paginator.NewFuncSource(func(offset, limit int) ([]int, error) {
+			return data[min(offset, len(data)):min(offset+limit, len(data))], nil
+		}, func() int { return len(data) })
+		p := paginator.NewPaginator([]paginator.DataSource[int]{src}, 2, paginator.WithSnapshot[int]())
+		h := Handler(p)
+		get(t, h, "/items?page_size=2")
+		data = append(data, 4)
+		rec, env := get(t, h, "/items?page=2&page_size=2")
+		if rec.Code != http.StatusOK || env.PageInfo.Drift == nil || !reflect.DeepEqual(env.Items, []int{3}) {
+			t.Errorf("status = %v, page = %v, %+v", rec.Code, env.Items, env.PageInfo)
+		}
+	})
+}
+
+func TestHandler_InternalError(t *testing.T) {
+	var logged error
+	p := paginator.NewPaginatorCtx([]paginator.DataSourceCtx[int]{failingSource{}}, 2, paginator.WithTokenKey[int]([]byte("k"), 0))
+	h := Handler(p, WithErrorHandler(func(_ *http.Request, err error) { logged = err }))
+
+	for _, target := range []string{"/items?page=1", "/items?token="} {
+		logged = nil
+		rec, _ := get(t, h, target)
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("%s status = %v", target, rec.Code)
+		}
+		if strings.Contains(rec.Body.String(), "10.0.0.7") {
+			t.Errorf("%s body leaks internal error: %s", target, rec.Body)
+		}
+		if logged == nil || !strings.Contains(logged.Error(), "connection refused") {
+			t.Errorf("%s ErrorHandler got %v", target, logged)
+		}
+	}
+}
+
+func TestHandler_PartialResults(t *testing.T) {
+	var logged error
+	p := paginator.NewPaginatorCtx([]paginator.DataSourceCtx[int]{
+		paginator.FromDataSource[int](paginator.NewSliceSource([]int{1, 2})),
+		paginator.Named[int]("flaky", failingSource{}),
+		paginator.FromDataSource[int](paginator.NewSliceSource([]int{3, 4, 5})),
+	}, 2, paginator.WithPartialResults[int]())
+	h := Handler(p, WithErrorHandler(func(_ *http.Request, err error) { logged = err }))
+
+	rec, env := get(t, h, "/items?page=1&page_size=4")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %v, body = %s", rec.Code, rec.Body)
+	}
+	if !reflect.DeepEqual(env.Items, []int{1, 2, 3, 4}) || !reflect.DeepEqual(env.SkippedSources, []string{"flaky"}) {
+		t.Errorf("items = %v, skipped = %v", env.Items, env.SkippedSources)
+	}
+	if env.PageInfo.TotalItems != 5 || env.PageInfo.TotalPages != 2 || !env.PageInfo.HasNext {
+		t.Errorf("page_info = %+v", env.PageInfo)
+	}
+	var srcErrs paginator.SourceErrors
+	if !errors.As(logged, &srcErrs) {
+		t.Errorf("ErrorHandler got %v, want SourceErrors", logged)
+	}
+}
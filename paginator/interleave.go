@@ -0,0 +1,183 @@
+package paginator
+
+import (
+	"context"
+)
+
+// WeightedSource 交错分页中的数据源及其每轮取数
+type WeightedSource[T any] struct {
+	Source DataSourceCtx[T]
+	Weight int // <=0 按 1 处理
+}
+
+// InterleavePaginator 按权重轮流从各数据源取数，如权重 3,1,1 时为 AAABC AAABC...；
+// 某个数据源取完后其余的继续轮转。页内容只由各数据源的 Total 决定，同一页总是相同的数据；
+// 轮转依赖精确总数，数据源返回 UnknownTotal 时 GetPage 返回 ErrUnknownTotal；数据源出错时返回 *SourceError
+type InterleavePaginator[T any] struct {
+	sources  []DataSourceCtx[T]
+	weights  []int
+	pageSize int
+}
+
+// interleaveState 轮转位置：当前轮到第 turn 个数据源，本轮已取 taken 个
+type interleaveState struct {
+	weights  []int
+	totals   []int
+	consumed []int
+	turn     int
+	taken    int
+}
+
+// run 一页中来自同一数据源的连续片段
+type run struct {
+	src    int
+	offset int
+	limit  int
+}
+
+func NewInterleavePaginator[T any](sources []WeightedSource[T], pageSize int) *InterleavePaginator[T] {
+	ip := &InterleavePaginator[T]{pageSize: pageSize}
+	for _, ws := range sources {
+		ip.sources = append(ip.sources, ws.Source)
+		ip.weights = append(ip.weights, max(ws.Weight, 1))
+	}
+	return ip
+}
+
+// GetPage 页码从0开始，没有数据源时返回空页
+func (ip *InterleavePaginator[T]) GetPage(ctx context.Context, pageNum int) ([]T, error) {
+	if pageNum < 0 {
+		return nil, nil
+	}
+	if len(ip.sources) == 0 {
+		return []T{}, nil
+	}
+	st := &interleaveState{
+		weights:  ip.weights,
+		totals:   make([]int, len(ip.sources)),
+		consumed: make([]int, len(ip.sources)),
+	}
+	for i, src := range ip.sources {
+		total, err := src.Total(ctx)
+		if err != nil {
+			return nil, newSourceError(i, src, err)
+		}
+		if total < 0 {
+			return nil, newSourceError(i, src, ErrUnknownTotal)
+		}
+		st.totals[i] = total
+	}
+
+	st.skip(pageNum * ip.pageSize)
+	runs := st.take(ip.pageSize)
+
+	// 每个数据源在一页内消费的区间是连续的，只需拉取一次
+	var (
+		chunks = make([][]T, len(ip.sources))
+		starts = make([]int, len(ip.sources))
+		counts = make([]int, len(ip.sources))
+	)
+	for _, r := range runs {
+		if counts[r.src] == 0 {
+			starts[r.src] = r.offset
+		}
+		counts[r.src] += r.limit
+	}
+	for i, cnt := range counts {
+		if cnt == 0 {
+			continue
+		}
+		chunk, err := ip.sources[i].GetChunk(ctx, starts[i], cnt)
+		if err != nil {
+			return nil, newSourceError(i, ip.sources[i], err)
+		}
+		chunks[i] = chunk
+	}
+
+	result := make([]T, 0, ip.pageSize)
+	for _, r := range runs {
+		chunk := chunks[r.src]
+		from := min(r.offset-starts[r.src], len(chunk))
+		result = append(result, chunk[from:min(from+r.limit, len(chunk))]...)
+	}
+	return result, nil
+}
+
+func (st *interleaveState) remaining(i int) int {
+	return st.totals[i] - st.consumed[i]
+}
+
+// skip 跳过 n 个元素，轮首且所有未取完的数据源都够一整轮时按整轮跳过
+func (st *interleaveState) skip(n int) {
+	for n > 0 {
+		if st.turn == 0 && st.taken == 0 {
+			var (
+				roundSize int
+				rounds    = -1
+			)
+			for i, w := range st.weights {
+				rem := st.remaining(i)
+				if rem == 0 {
+					continue
+				}
+				if rem < w {
+					rounds = 0
+					break
+				}
+				roundSize += w
+				if rounds < 0 || rem/w < rounds {
+					rounds = rem / w
+				}
+			}
+			if rounds < 0 {
+				return // 全部取完
+			}
+			if roundSize > 0 {
+				if skip := min(rounds, n/roundSize); skip > 0 {
+					for i, w := range st.weights {
+						if st.remaining(i) > 0 {
+							st.consumed[i] += skip * w
+						}
+					}
+					n -= skip * roundSize
+					continue
+				}
+			}
+		}
+		moved := st.step(n, nil)
+		if moved < 0 {
+			return
+		}
+		n -= moved
+	}
+}
+
+// take 取 n 个元素，返回对应的片段
+func (st *interleaveState) take(n int) []run {
+	var runs []run
+	for n > 0 {
+		moved := st.step(n, func(r run) { runs = append(runs, r) })
+		if moved < 0 {
+			break
+		}
+		n -= moved
+	}
+	return runs
+}
+
+// step 在当前数据源的本轮配额内最多移动 n 个，配额用完或数据源取完时轮到下一个；全部取完返回 -1
+func (st *interleaveState) step(n int, emit func(run)) int {
+	for tries := 0; tries <= len(st.weights); tries++ {
+		i := st.turn
+		if cnt := min(st.weights[i]-st.taken, st.remaining(i), n); cnt > 0 {
+			if emit != nil {
+				emit(run{src: i, offset: st.consumed[i], limit: cnt})
+			}
+			st.consumed[i] += cnt
+			st.taken += cnt
+			return cnt
+		}
+		st.turn, st.taken = (i+1)%len(st.weights), 0
+	}
+	return -1
+}
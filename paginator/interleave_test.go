@@ -0,0 +1,128 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type labelSource struct {
+	label string
+	total int
+}
+
+func (s *labelSource) GetChunk(_ context.Context, offset, limit int) ([]string, error) {
+	var ret []string
+	for i := offset; i < min(offset+limit, s.total); i++ {
+		ret = append(ret, s.label+string(rune('0'+i%10)))
+	}
+	return ret, nil
+}
+
+func (s *labelSource) Total(context.Context) (int, error) {
+	return s.total, nil
+}
+
+// interleaveNaive 逐个模拟，作为对照
+func interleaveNaive(weights, totals []int) (ret [][2]int) {
+	consumed := make([]int, len(totals))
+	for {
+		progress := false
+		for i, w := range weights {
+			for j := 0; j < w && consumed[i] < totals[i]; j++ {
+				ret = append(ret, [2]int{i, consumed[i]})
+				consumed[i]++
+				progress = true
+			}
+		}
+		if !progress {
+			return ret
+		}
+	}
+}
+
+func TestInterleavePaginator(t *testing.T) {
+	ip := NewInterleavePaginator([]WeightedSource[string]{
+		{Source: &labelSource{"A", 7}, Weight: 3},
+		{Source: &labelSource{"B", 2}, Weight: 1},
+		{Source: &labelSource{"C", 4}, Weight: 1},
+	}, 4)
+
+	var all []string
+	for n := 0; ; n++ {
+		page, err := ip.GetPage(context.Background(), n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		all = append(all, page...)
+	}
+	want := []string{"A0", "A1", "A2", "B0", "C0", "A3", "A4", "A5", "B1", "C1", "A6", "C2", "C3"}
+	if !reflect.DeepEqual(all, want) {
+		t.Errorf("pages = %v, want %v", all, want)
+	}
+
+	// 同一页结果稳定
+	p2a, _ := ip.GetPage(context.Background(), 2)
+	p2b, _ := ip.GetPage(context.Background(), 2)
+	if !reflect.DeepEqual(p2a, p2b) || !reflect.DeepEqual(p2a, want[8:12]) {
+		t.Errorf("page 2 = %v / %v", p2a, p2b)
+	}
+
+	t.Run("unknown total", func(t *testing.T) {
+		ip := NewInterleavePaginator([]WeightedSource[string]{
+			{Source: &labelSource{"A", 3}},
+			{Source: &labelSource{"B", UnknownTotal}},
+		}, 2)
+		if page, err := ip.GetPage(context.Background(), 0); !errors.Is(err, ErrUnknownTotal) {
+			t.Errorf("GetPage() = %v, %v, want ErrUnknownTotal", page, err)
+		}
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		errDown := errors.New("down")
+		ip := NewInterleavePaginator([]WeightedSource[int]{
+			{Source: &ctxSource{total: 3}},
+			{Source: Named[int]("ads", &ctxSource{total: 3, chunkErr: errDown})},
+			{Source: &ctxSource{totalErr: errDown}},
+		}, 2)
+		var srcErr *SourceError
+		if _, err := ip.GetPage(context.Background(), 0); !errors.As(err, &srcErr) || srcErr.Name != "#2" {
+			t.Errorf("GetPage() error = %v, want SourceError for #2", err)
+		}
+		ip.sources = ip.sources[:2]
+		if _, err := ip.GetPage(context.Background(), 0); !errors.As(err, &srcErr) || srcErr.Name != "ads" || !errors.Is(err, errDown) {
+			t.Errorf("GetPage() error = %v, want SourceError for ads", err)
+		}
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		page, err := NewInterleavePaginator[int](nil, 3).GetPage(context.Background(), 0)
+		if err != nil || page == nil || len(page) != 0 {
+			t.Errorf("GetPage() = %#v, %v, want empty page", page, err)
+		}
+	})
+}
+
+func TestInterleaveState_Skip(t *testing.T) {
+	weights := []int{3, 1, 2}
+	totals := []int{100, 7, 31}
+	naive := interleaveNaive(weights, totals)
+	for skip := 0; skip <= len(naive); skip++ {
+		st := &interleaveState{weights: weights, totals: totals, consumed: make([]int, 3)}
+		st.skip(skip)
+		runs := st.take(1)
+		if skip == len(naive) {
+			if len(runs) != 0 {
+				t.Errorf("skip %d: got %v after end", skip, runs)
+			}
+			continue
+		}
+		if len(runs) != 1 || runs[0].src != naive[skip][0] || runs[0].offset != naive[skip][1] {
+			t.Fatalf("skip %d: got %v, want %v", skip, runs, naive[skip])
+		}
+	}
+}
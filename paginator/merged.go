@@ -0,0 +1,162 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/1298509345/go-utils-frequently/ds/heap"
+)
+
+var ErrInvalidCursor = errors.New("paginator: invalid cursor")
+
+// Cursor 各数据源已消费的元素个数，可用于断点续读
+type Cursor []int
+
+// MergedPaginator 对多个各自有序的数据源做 k 路归并分页，每页最多向每个数据源拉取 pageSize 个元素；
+// 相等元素按数据源顺序排列，保证分页稳定
+type MergedPaginator[T any] struct {
+	sources  []DataSourceCtx[T]
+	pageSize int
+	less     func(a, b T) bool
+
+	mu      sync.Mutex
+	cursors []Cursor // cursors[n] 为第 n 页的起始位置，顺序翻页时不必从头归并
+}
+
+type mergeItem[T any] struct {
+	val T
+	src int
+}
+
+// mergeReader 按块读取单个数据源
+type mergeReader[T any] struct {
+	idx    int
+	src    DataSourceCtx[T]
+	offset int // 下一次拉取的起点
+	limit  int
+	buf    []T
+	eof    bool
+}
+
+func NewMergedPaginator[T any](sources []DataSourceCtx[T], pageSize int, less func(a, b T) bool) *MergedPaginator[T] {
+	cpSource := make([]DataSourceCtx[T], len(sources))
+	copy(cpSource, sources)
+	return &MergedPaginator[T]{
+		sources:  cpSource,
+		pageSize: pageSize,
+		less:     less,
+		cursors:  []Cursor{make(Cursor, len(sources))},
+	}
+}
+
+// GetPage 页码从0开始；跳页时从最近的已知页起点归并到目标页
+func (m *MergedPaginator[T]) GetPage(ctx context.Context, pageNum int) ([]T, error) {
+	if pageNum < 0 {
+		return nil, nil
+	}
+
+	m.mu.Lock()
+	known := min(pageNum, len(m.cursors)-1)
+	cursor := m.cursors[known]
+	m.mu.Unlock()
+
+	for ; ; known++ {
+		items, next, err := m.PageFrom(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == m.pageSize {
+			m.remember(known+1, next)
+		}
+		if known == pageNum {
+			return items, nil
+		}
+		if len(items) < m.pageSize {
+			return nil, nil
+		}
+		cursor = next
+	}
+}
+
+func (m *MergedPaginator[T]) remember(pageNum int, cursor Cursor) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if pageNum == len(m.cursors) {
+		m.cursors = append(m.cursors, cursor)
+	}
+}
+
+// PageFrom 从 cursor 处取一页，返回下一页的 cursor；cursor 为空表示从头开始，返回空切片表示已读完；
+// 数据源出错时返回 *SourceError
+func (m *MergedPaginator[T]) PageFrom(ctx context.Context, cursor Cursor) ([]T, Cursor, error) {
+	if len(cursor) == 0 {
+		cursor = make(Cursor, len(m.sources))
+	}
+	if len(cursor) != len(m.sources) {
+		return nil, nil, ErrInvalidCursor
+	}
+
+	var (
+		next    = slices.Clone(cursor)
+		readers = make([]*mergeReader[T], len(m.sources))
+		result  = make([]T, 0, m.pageSize)
+		h       = heap.New(len(m.sources), func(a, b mergeItem[T]) bool {
+			if m.less(a.val, b.val) {
+				return true
+			}
+			return !m.less(b.val, a.val) && a.src < b.src
+		})
+	)
+	for i, src := range m.sources {
+		if cursor[i] < 0 {
+			return nil, nil, ErrInvalidCursor
+		}
+		readers[i] = &mergeReader[T]{idx: i, src: src, offset: cursor[i], limit: m.pageSize}
+		val, ok, err := readers[i].next(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			h.Push(mergeItem[T]{val: val, src: i})
+		}
+	}
+
+	for len(result) < m.pageSize {
+		top, ok := h.Pop()
+		if !ok {
+			break
+		}
+		result = append(result, top.val)
+		next[top.src]++
+		if len(result) == m.pageSize {
+			break
+		}
+		val, ok, err := readers[top.src].next(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			h.Push(mergeItem[T]{val: val, src: top.src})
+		}
+	}
+	return result, next, nil
+}
+
+func (r *mergeReader[T]) next(ctx context.Context) (val T, ok bool, err error) {
+	if len(r.buf) == 0 && !r.eof {
+		chunk, err := r.src.GetChunk(ctx, r.offset, r.limit)
+		if err != nil {
+			return val, false, newSourceError(r.idx, r.src, err)
+		}
+		r.offset += len(chunk)
+		r.buf = chunk
+		r.eof = len(chunk) < r.limit
+	}
+	if len(r.buf) == 0 {
+		return val, false, nil
+	}
+	val, r.buf = r.buf[0], r.buf[1:]
+	return val, true, nil
+}
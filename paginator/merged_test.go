@@ -0,0 +1,134 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type event struct {
+	TS  int
+	Src string
+}
+
+type eventSource struct {
+	data    []event
+	fetched int
+}
+
+func (s *eventSource) GetChunk(_ context.Context, offset, limit int) ([]event, error) {
+	if offset >= len(s.data) {
+		return nil, nil
+	}
+	chunk := s.data[offset:min(offset+limit, len(s.data))]
+	s.fetched += len(chunk)
+	return chunk, nil
+}
+
+func (s *eventSource) Total(context.Context) (int, error) {
+	return len(s.data), nil
+}
+
+func newEventSources() []*eventSource {
+	return []*eventSource{
+		{data: []event{{1, "a"}, {4, "a"}, {4, "a"}, {9, "a"}, {12, "a"}}},
+		{data: []event{{2, "b"}, {4, "b"}, {5, "b"}}},
+		{data: []event{{0, "c"}, {3, "c"}, {4, "c"}, {10, "c"}, {11, "c"}, {13, "c"}}},
+	}
+}
+
+func TestMergedPaginator(t *testing.T) {
+	var (
+		raw     = newEventSources()
+		sources []DataSourceCtx[event]
+		want    []event
+	)
+	for _, s := range raw {
+		sources = append(sources, s)
+		want = append(want, s.data...)
+	}
+	sort.SliceStable(want, func(i, j int) bool { return want[i].TS < want[j].TS })
+
+	m := NewMergedPaginator(sources, 4, func(a, b event) bool { return a.TS < b.TS })
+	page, err := m.GetPage(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(page, want[:4]) {
+		t.Errorf("page 0 = %v, want %v", page, want[:4])
+	}
+	for _, s := range raw {
+		if s.fetched > 4 {
+			t.Errorf("source loaded %d items for one page", s.fetched)
+		}
+	}
+
+	var got []event
+	for i := 0; ; i++ {
+		page, err := m.GetPage(context.Background(), i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		got = append(got, page...)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merged = %v, want %v", got, want)
+	}
+
+	// 跳页与顺序翻页结果一致
+	jump := NewMergedPaginator(sources, 4, func(a, b event) bool { return a.TS < b.TS })
+	if page, _ := jump.GetPage(context.Background(), 3); !reflect.DeepEqual(page, want[12:]) {
+		t.Errorf("page 3 = %v, want %v", page, want[12:])
+	}
+	if page, _ := jump.GetPage(context.Background(), 9); page != nil {
+		t.Errorf("page 9 = %v, want nil", page)
+	}
+}
+
+func TestMergedPaginator_PageFrom(t *testing.T) {
+	var sources []DataSourceCtx[event]
+	for _, s := range newEventSources() {
+		sources = append(sources, s)
+	}
+	m := NewMergedPaginator(sources, 5, func(a, b event) bool { return a.TS < b.TS })
+
+	first, cursor, err := m.PageFrom(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Cursor{2, 1, 2}); !reflect.DeepEqual(cursor, want) {
+		t.Errorf("cursor = %v, want %v (page %v)", cursor, want, first)
+	}
+
+	// 续读结果与 GetPage(1) 一致
+	second, _, err := m.PageFrom(context.Background(), cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page, _ := m.GetPage(context.Background(), 1); !reflect.DeepEqual(page, second) {
+		t.Errorf("PageFrom() = %v, GetPage(1) = %v", second, page)
+	}
+
+	if _, _, err := m.PageFrom(context.Background(), Cursor{1}); err != ErrInvalidCursor {
+		t.Errorf("PageFrom() error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestMergedPaginator_SourceError(t *testing.T) {
+	errDown := errors.New("down")
+	failing := NewFuncSource(func(int, int) ([]event, error) { return nil, errDown }, func() int { return 1 })
+	m := NewMergedPaginator([]DataSourceCtx[event]{
+		newEventSources()[0],
+		Named[event]("audit", FromDataSource[event](failing)),
+	}, 5, func(a, b event) bool { return a.TS < b.TS })
+
+	var srcErr *SourceError
+	if _, err := m.GetPage(context.Background(), 0); !errors.As(err, &srcErr) || srcErr.Name != "audit" || srcErr.Index != 1 || !errors.Is(err, errDown) {
+		t.Errorf("GetPage() error = %v, want SourceError for audit", err)
+	}
+}
@@ -1,56 +1,394 @@
 package paginator
 
-// DataSource 数据源通用接口
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/1298509345/go-utils-frequently/optional"
+	"golang.org/x/sync/errgroup"
+)
+
+// DataSource 数据源通用接口，无法低成本给出总数时 Total 返回 UnknownTotal
 type DataSource[T any] interface {
 	GetChunk(offset int, limit int) ([]T, error)
 	Total() int
 }
 
+// DataSourceCtx 支持超时取消的数据源，Total 可返回错误或 UnknownTotal
+type DataSourceCtx[T any] interface {
+	GetChunk(ctx context.Context, offset int, limit int) ([]T, error)
+	Total(ctx context.Context) (int, error)
+}
+
+// FromDataSource 把 DataSource 适配为 DataSourceCtx，调用前检查 ctx 是否已结束
+func FromDataSource[T any](src DataSource[T]) DataSourceCtx[T] {
+	return dataSourceAdapter[T]{src: src}
+}
+
+type dataSourceAdapter[T any] struct {
+	src DataSource[T]
+}
+
+func (a dataSourceAdapter[T]) GetChunk(ctx context.Context, offset int, limit int) ([]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.src.GetChunk(offset, limit)
+}
+
+func (a dataSourceAdapter[T]) Total(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return a.src.Total(), nil
+}
+
 type Paginator[T any] struct {
-	sources  []DataSource[T]
+	sources  []DataSourceCtx[T]
 	pageSize int
+
+	cacheTotals bool
+	totalsTTL   time.Duration // <=0 时缓存只在 Refresh 后失效
+	parallel    bool
+	pageBase    int // 第一页的页码，0 或 1
+	tokenKey    []byte
+	tokenTTL    time.Duration
+	prefetch    bool
+	snapshot    bool
+	partial     bool
+	reverse     bool
+	now         func() time.Time
+
+	mu       sync.Mutex
+	totals   []int
+	totalsAt time.Time
+
+	pfMu       sync.Mutex
+	prefetched *prefetchPage[T] // 最多一个进行中或已完成的预取
+
+	sizeMu  sync.Mutex // 只保护探测状态，不在探测 I/O 期间持有
+	lower   []int      // 未知总数的数据源已探测到的大小
+	exact   []bool     // 该数据源已读到末尾
+	sizeGen int        // Refresh 时递增
+}
+
+// segment 一次分页请求落在某个数据源上的区间
+type segment struct {
+	src    int
+	offset int
+	limit  int
+}
+
+func NewPaginator[T any](sources []DataSource[T], pageSize int, options ...optional.Op[Paginator[T]]) *Paginator[T] {
+	cpSource := make([]DataSourceCtx[T], len(sources))
+	for i, src := range sources {
+		cpSource[i] = FromDataSource(src)
+	}
+	return newPaginator(cpSource, pageSize, options...)
 }
 
-func NewPaginator[T any](sources []DataSource[T], pageSize int) *Paginator[T] {
-	cpSource := make([]DataSource[T], len(sources))
+func NewPaginatorCtx[T any](sources []DataSourceCtx[T], pageSize int, options ...optional.Op[Paginator[T]]) *Paginator[T] {
+	cpSource := make([]DataSourceCtx[T], len(sources))
 	copy(cpSource, sources)
-	return &Paginator[T]{
-		sources:  cpSource,
+	return newPaginator(cpSource, pageSize, options...)
+}
+
+func newPaginator[T any](sources []DataSourceCtx[T], pageSize int, options ...optional.Op[Paginator[T]]) *Paginator[T] {
+	p := &Paginator[T]{
+		sources:  sources,
 		pageSize: pageSize,
+		now:      time.Now,
 	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// WithTotalsCache 缓存各数据源的 Total，ttl<=0 时只有调用 Refresh 才会重新获取
+func WithTotalsCache[T any](ttl time.Duration) optional.Op[Paginator[T]] {
+	return func(p *Paginator[T]) {
+		p.cacheTotals = true
+		p.totalsTTL = ttl
+	}
+}
+
+// WithParallelFetch 并行获取所有 Total，一页跨多个数据源时并行拉取各段，结果仍按数据源顺序拼接
+func WithParallelFetch[T any]() optional.Op[Paginator[T]] {
+	return func(p *Paginator[T]) {
+		p.parallel = true
+	}
+}
+
+// WithPageBase 第一页的页码，只支持 0（默认）或 1
+func WithPageBase[T any](base int) optional.Op[Paginator[T]] {
+	return func(p *Paginator[T]) {
+		if base == 0 || base == 1 {
+			p.pageBase = base
+		}
+	}
+}
+
+// Refresh 使缓存的 Total 及探测到的未知数据源大小失效，下一次请求重新获取
+func (p *Paginator[T]) Refresh() {
+	p.mu.Lock()
+	p.totals = nil
+	p.mu.Unlock()
+	p.resetSizes()
 }
 
 func (p *Paginator[T]) GetPage(pageNum int) ([]T, error) {
-	if pageNum <= 0 {
-		return nil, nil
+	return p.GetPageCtx(context.Background(), pageNum)
+}
+
+// GetPageCtx 任一数据源出错或 ctx 结束时取消其余进行中的请求；页码小于 pageBase 时返回 nil
+func (p *Paginator[T]) GetPageCtx(ctx context.Context, pageNum int) ([]T, error) {
+	items, _, err := p.GetPageInfoCtx(ctx, pageNum)
+	return items, err
+}
+
+// PageInfo 分页元数据，Page 与请求的页码一致（按 pageBase 计）
+type PageInfo struct {
+	Page       int  `json:"page"`
+	PageSize   int  `json:"page_size"`
+	TotalItems int  `json:"total_items"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+	Estimated  bool `json:"estimated,omitempty"` // 存在未探测完的未知总数数据源，TotalItems 为下界
+
+	Drift *DriftError `json:"drift,omitempty"` // 快照模式下当前 Total 与快照不一致
+}
+
+func (p *Paginator[T]) GetPageInfo(pageNum int) ([]T, PageInfo, error) {
+	return p.GetPageInfoCtx(context.Background(), pageNum)
+}
+
+func (p *Paginator[T]) GetPageInfoCtx(ctx context.Context, pageNum int) ([]T, PageInfo, error) {
+	if !p.prefetch {
+		return p.loadPageInfo(ctx, pageNum)
 	}
+	return p.getPageWithPrefetch(ctx, pageNum)
+}
+
+// GetPageSizedCtx 与 GetPageInfoCtx 相同但按 pageSize 分页，页大小不同于默认值时不使用预取；pageSize<=0 时使用默认页大小
+func (p *Paginator[T]) GetPageSizedCtx(ctx context.Context, pageNum, pageSize int) ([]T, PageInfo, error) {
+	if pageSize <= 0 || pageSize == p.pageSize {
+		return p.GetPageInfoCtx(ctx, pageNum)
+	}
+	return p.loadPage(ctx, pageNum, pageSize)
+}
+
+// PageBase 第一页的页码
+func (p *Paginator[T]) PageBase() int {
+	return p.pageBase
+}
+
+func (p *Paginator[T]) loadPageInfo(ctx context.Context, pageNum int) ([]T, PageInfo, error) {
+	return p.loadPage(ctx, pageNum, p.pageSize)
+}
+
+func (p *Paginator[T]) loadPage(ctx context.Context, pageNum, pageSize int) ([]T, PageInfo, error) {
+	totals, err := p.getTotals(ctx)
+	srcErrs, partial := p.partialErr(err)
+	if err != nil && !partial {
+		return nil, PageInfo{}, err
+	}
+	need := math.MaxInt
+	if !p.reverse && pageNum >= p.pageBase {
+		need = (pageNum - p.pageBase + 1) * pageSize
+	}
+	totals, estimated, resolveErr := p.resolveTotals(ctx, totals, need)
+	probeErrs, partial := p.partialErr(resolveErr)
+	if resolveErr != nil && !partial {
+		return nil, PageInfo{}, resolveErr
+	}
+	srcErrs = srcErrs.merge(probeErrs)
+
+	if pageNum < p.pageBase {
+		info := p.pageInfo(totals, pageNum, pageSize)
+		info.Estimated = estimated
+		return nil, info, srcErrs.orNil()
+	}
+	// partial 模式下取数失败的数据源会按0重新规划，PageInfo 使用最终规划的 totals，与页内容一致
+	items, totals, err := p.fetchPlanned(ctx, totals, srcErrs, func(totals []int) (int, int) {
+		return p.pageRange(totals, pageNum-p.pageBase, pageSize)
+	})
+	if _, partial := p.partialErr(err); err != nil && !partial {
+		return nil, PageInfo{}, err
+	}
+	info := p.pageInfo(totals, pageNum, pageSize)
+	info.Estimated = estimated
+	if estimated && len(items) == pageSize {
+		info.HasNext = true // 后面可能还有数据
+	}
+	if p.reverse {
+		slices.Reverse(items)
+	}
+	if p.snapshot {
+		// 快照模式下数据仍按快照返回，漂移只作为提示；partial 模式下当前 Total 不完整时不判断漂移
+		drift, driftErr := p.checkDrift(ctx)
+		if _, partial := p.partialErr(driftErr); driftErr != nil && !partial {
+			return nil, PageInfo{}, driftErr
+		}
+		info.Drift = drift
+	}
+	return items, info, err
+}
+
+func (p *Paginator[T]) pageInfo(totals []int, pageNum, pageSize int) PageInfo {
+	info := PageInfo{Page: pageNum, PageSize: pageSize}
+	for _, total := range totals {
+		info.TotalItems += total
+	}
+	if pageSize > 0 {
+		info.TotalPages = (info.TotalItems + pageSize - 1) / pageSize
+	}
+	idx := pageNum - p.pageBase
+	info.HasPrev = idx > 0 && idx <= info.TotalPages
+	info.HasNext = idx >= 0 && idx+1 < info.TotalPages
+	return info
+}
+
+func (p *Paginator[T]) getTotals(ctx context.Context) ([]int, error) {
+	if !p.cacheTotals && !p.snapshot {
+		return p.loadTotals(ctx)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.totals != nil && (p.snapshot || p.totalsTTL <= 0 || p.now().Sub(p.totalsAt) < p.totalsTTL) {
+		return p.totals, nil
+	}
+	totals, err := p.loadTotals(ctx)
+	if _, partial := p.partialErr(err); partial {
+		return totals, err // 有数据源失败时不缓存
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.totals, p.totalsAt = totals, p.now()
+	return totals, nil
+}
+
+// loadTotals partial 模式下失败的数据源 Total 记为0，并返回 SourceErrors
+func (p *Paginator[T]) loadTotals(ctx context.Context) ([]int, error) {
 	var (
-		skippedSoFar = pageNum * p.pageSize
-		result       = make([]T, 0, p.pageSize)
+		totals = make([]int, len(p.sources))
+		errs   = make([]error, len(p.sources))
 	)
+	if !p.parallel {
+		for i, src := range p.sources {
+			if totals[i], errs[i] = src.Total(ctx); errs[i] != nil && !p.partial {
+				return nil, p.sourceErr(i, errs[i])
+			}
+		}
+	} else {
+		eg, egCtx := errgroup.WithContext(ctx)
+		if p.partial {
+			egCtx = ctx // 单个数据源失败不取消其他数据源
+		}
+		for i, src := range p.sources {
+			i, src := i, src
+			eg.Go(func() error {
+				if totals[i], errs[i] = src.Total(egCtx); errs[i] != nil && !p.partial {
+					return p.sourceErr(i, errs[i])
+				}
+				return nil
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return nil, err
+		}
+	}
+
+	srcErrs := SourceErrors{}
+	for i, err := range errs {
+		if err != nil {
+			totals[i] = 0
+			srcErrs[p.sourceName(i)] = p.sourceErr(i, err)
+		}
+	}
+	if len(srcErrs) > 0 {
+		return totals, srcErrs
+	}
+	return totals, nil
+}
 
-	// 遍历所有数据源获取元素
-	for _, src := range p.sources {
-		if skippedSoFar >= src.Total() {
-			skippedSoFar -= src.Total()
+// plan 把全局区间 [offset, offset+limit) 拆分到各数据源
+func (p *Paginator[T]) plan(totals []int, offset, limit int) []segment {
+	var segments []segment
+	for i, total := range totals {
+		if limit <= 0 {
+			break
+		}
+		if offset >= total {
+			offset -= total
 			continue
 		}
 
 		// 计算当前数据源的有效区间
-		remaining := src.Total() - skippedSoFar
-		take := min(remaining, p.pageSize-len(result))
+		take := min(total-offset, limit)
+		segments = append(segments, segment{src: i, offset: offset, limit: take})
+		limit -= take
+		offset = 0 // 后续数据源从0开始
+	}
+	return segments
+}
 
-		chunk, err := src.GetChunk(skippedSoFar, take)
+func (p *Paginator[T]) fetch(ctx context.Context, segments []segment) ([]T, error) {
+	chunks, _, err := p.fetchSegments(ctx, segments)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, 0, p.pageSize)
+	for _, chunk := range chunks {
+		result = append(result, chunk...)
+	}
+	return result, nil
+}
+
+// fetchSegments 拉取各段，errs 为各段的错误，firstErr 为最先发生的错误；非 partial 模式下第一个错误会取消其他并行请求
+func (p *Paginator[T]) fetchSegments(ctx context.Context, segments []segment) (chunks [][]T, errs []error, firstErr error) {
+	chunks = make([][]T, len(segments))
+	errs = make([]error, len(segments))
+	get := func(ctx context.Context, i int) error {
+		seg := segments[i]
+		chunk, err := p.sources[seg.src].GetChunk(ctx, seg.offset, seg.limit)
 		if err != nil {
-			return nil, err
+			errs[i] = p.sourceErr(seg.src, err)
+			return errs[i]
 		}
-		result = append(result, chunk...)
+		chunks[i] = chunk
+		return nil
+	}
 
-		skippedSoFar = 0 // 后续数据源从0开始
-		if len(result) == p.pageSize {
-			break
+	if !p.parallel || len(segments) <= 1 {
+		for i := range segments {
+			if err := get(ctx, i); err != nil {
+				firstErr = cmp.Or(firstErr, err)
+				if !p.partial {
+					break
+				}
+			}
 		}
+		return chunks, errs, firstErr
 	}
 
-	return result, nil
+	eg, egCtx := errgroup.WithContext(ctx)
+	if p.partial {
+		egCtx = ctx
+	}
+	for i := range segments {
+		i := i
+		eg.Go(func() error {
+			return get(egCtx, i)
+		})
+	}
+	return chunks, errs, eg.Wait()
 }
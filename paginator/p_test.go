@@ -1,22 +1,15 @@
 package paginator
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
-type SliceSource[T any] struct {
-	data []T
-}
-
-func (s *SliceSource[T]) GetChunk(offset, limit int) ([]T, error) {
-	end := min(offset+limit, len(s.data))
-	return s.data[offset:end], nil
-}
-
-func (s *SliceSource[T]) Total() int {
-	return len(s.data)
-}
-
 func TestPag(t *testing.T) {
 	src1 := &SliceSource[int]{data: []int{1, 2, 3}}
 	src2 := &SliceSource[int]{data: []int{4, 5, 6, 7, 8, 9, 10}}
@@ -25,11 +18,266 @@ func TestPag(t *testing.T) {
 	paginator := NewPaginator[int]([]DataSource[int]{src1, src2, src3}, 3)
 
 	// 分页验证
+	var all []int
 	for i := 0; true; i++ {
 		page, _ := paginator.GetPage(i)
 		if len(page) == 0 {
 			break
 		}
 		t.Log(page)
+		all = append(all, page...)
+	}
+	if len(all) != 13 || all[0] != 1 || all[12] != 13 {
+		t.Errorf("pages = %v", all)
+	}
+}
+
+func TestPaginator_GetPageInfo(t *testing.T) {
+	sources := []DataSource[int]{
+		&SliceSource[int]{data: []int{1, 2, 3}},
+		&SliceSource[int]{data: []int{4, 5, 6, 7}},
+	}
+	type testCase struct {
+		name      string
+		base      int
+		pageNum   int
+		wantItems []int
+		wantInfo  PageInfo
+	}
+	tests := []testCase{
+		{"0-based first", 0, 0, []int{1, 2, 3}, PageInfo{Page: 0, PageSize: 3, TotalItems: 7, TotalPages: 3, HasNext: true}},
+		{"0-based last", 0, 2, []int{7}, PageInfo{Page: 2, PageSize: 3, TotalItems: 7, TotalPages: 3, HasPrev: true}},
+		{"1-based first", 1, 1, []int{1, 2, 3}, PageInfo{Page: 1, PageSize: 3, TotalItems: 7, TotalPages: 3, HasNext: true}},
+		{"1-based middle", 1, 2, []int{4, 5, 6}, PageInfo{Page: 2, PageSize: 3, TotalItems: 7, TotalPages: 3, HasNext: true, HasPrev: true}},
+		{"1-based zero", 1, 0, nil, PageInfo{Page: 0, PageSize: 3, TotalItems: 7, TotalPages: 3}},
+		{"beyond", 1, 4, []int{}, PageInfo{Page: 4, PageSize: 3, TotalItems: 7, TotalPages: 3, HasPrev: true}},
+		{"far beyond", 1, 6, []int{}, PageInfo{Page: 6, PageSize: 3, TotalItems: 7, TotalPages: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPaginator(sources, 3, WithPageBase[int](tt.base))
+			items, info, err := p.GetPageInfo(tt.pageNum)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("items = %v, want %v", items, tt.wantItems)
+			}
+			if info != tt.wantInfo {
+				t.Errorf("info = %+v, want %+v", info, tt.wantInfo)
+			}
+		})
+	}
+}
+
+type countingSource[T any] struct {
+	SliceSource[T]
+	totalCalls atomic.Int32
+	delay      time.Duration
+	overlap    *overlapTracker
+}
+
+func (s *countingSource[T]) GetChunk(offset, limit int) ([]T, error) {
+	if s.overlap != nil {
+		s.overlap.enter()
+		defer s.overlap.leave()
+	}
+	time.Sleep(s.delay)
+	return s.SliceSource.GetChunk(offset, limit)
+}
+
+// overlapTracker 统计同时进行的调用数；enter 等到 want 个调用同时进行（或超时）再返回，
+// 并行实现一定能观察到 want 个重叠，串行实现只会观察到1个
+type overlapTracker struct {
+	mu         sync.Mutex
+	running    int
+	maxRunning int
+	want       int
+	ready      chan struct{}
+}
+
+func newOverlapTracker(want int) *overlapTracker {
+	return &overlapTracker{want: want, ready: make(chan struct{})}
+}
+
+func (o *overlapTracker) enter() {
+	o.mu.Lock()
+	o.running++
+	if o.running > o.maxRunning {
+		o.maxRunning = o.running
+		if o.maxRunning == o.want {
+			close(o.ready)
+		}
+	}
+	o.mu.Unlock()
+
+	select {
+	case <-o.ready:
+	case <-time.After(time.Second):
+	}
+}
+
+func (o *overlapTracker) leave() {
+	o.mu.Lock()
+	o.running--
+	o.mu.Unlock()
+}
+
+// MaxOverlap 观察到的最大并发调用数
+func (o *overlapTracker) MaxOverlap() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.maxRunning
+}
+
+func (s *countingSource[T]) Total() int {
+	s.totalCalls.Add(1)
+	time.Sleep(s.delay)
+	return s.SliceSource.Total()
+}
+
+func TestPaginator_TotalsCache(t *testing.T) {
+	var (
+		now  = time.Unix(0, 0)
+		src1 = &countingSource[int]{SliceSource: SliceSource[int]{data: []int{1, 2, 3}}}
+		src2 = &countingSource[int]{SliceSource: SliceSource[int]{data: []int{4, 5, 6, 7}}}
+		p    = NewPaginator([]DataSource[int]{src1, src2}, 2, WithTotalsCache[int](time.Minute), WithParallelFetch[int]())
+	)
+	p.now = func() time.Time { return now }
+
+	for i := 1; i <= 3; i++ {
+		if _, err := p.GetPage(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if src1.totalCalls.Load() != 1 || src2.totalCalls.Load() != 1 {
+		t.Errorf("Total() called %v/%v times, want 1", src1.totalCalls.Load(), src2.totalCalls.Load())
+	}
+
+	now = now.Add(time.Minute)
+	_, _ = p.GetPage(1)
+	p.Refresh()
+	_, _ = p.GetPage(1)
+	if src1.totalCalls.Load() != 3 {
+		t.Errorf("Total() called %v times after ttl and Refresh, want 3", src1.totalCalls.Load())
+	}
+}
+
+func TestPaginator_ParallelFetch(t *testing.T) {
+	// 第1页跨3个数据源
+	overlap := newOverlapTracker(3)
+	var sources []DataSource[int]
+	for i := 0; i < 5; i++ {
+		sources = append(sources, &countingSource[int]{
+			SliceSource: SliceSource[int]{data: []int{i * 2, i*2 + 1}},
+			overlap:     overlap,
+		})
+	}
+	p := NewPaginator(sources, 5, WithParallelFetch[int]())
+
+	got, err := p.GetPage(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{5, 6, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPage() = %v, want %v", got, want)
+	}
+	if n := overlap.MaxOverlap(); n != 3 {
+		t.Errorf("MaxOverlap() = %v, want 3", n)
+	}
+}
+
+type ctxSource struct {
+	total    int
+	totalErr error
+	chunkErr error
+	block    bool // GetChunk 阻塞到 ctx 结束
+}
+
+func (s *ctxSource) GetChunk(ctx context.Context, offset, limit int) ([]int, error) {
+	if s.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if s.chunkErr != nil {
+		return nil, s.chunkErr
+	}
+	ret := make([]int, 0, limit)
+	for i := offset; i < min(offset+limit, s.total); i++ {
+		ret = append(ret, i)
+	}
+	return ret, nil
+}
+
+func (s *ctxSource) Total(context.Context) (int, error) {
+	return s.total, s.totalErr
+}
+
+func TestPaginator_GetPageCtx(t *testing.T) {
+	errDown := errors.New("down")
+
+	t.Run("total error", func(t *testing.T) {
+		p := NewPaginatorCtx([]DataSourceCtx[int]{&ctxSource{total: 3}, &ctxSource{totalErr: errDown}}, 2)
+		if _, err := p.GetPageCtx(context.Background(), 1); !errors.Is(err, errDown) {
+			t.Errorf("GetPageCtx() error = %v, want %v", err, errDown)
+		}
+	})
+
+	t.Run("cancel in-flight", func(t *testing.T) {
+		p := NewPaginatorCtx([]DataSourceCtx[int]{
+			&ctxSource{total: 3, block: true},
+			&ctxSource{total: 3, chunkErr: errDown},
+		}, 2, WithParallelFetch[int]())
+		// 第1页跨两个源：[2,3) 和 [0,1)
+		if _, err := p.GetPageCtx(context.Background(), 1); !errors.Is(err, errDown) {
+			t.Errorf("GetPageCtx() error = %v, want %v", err, errDown)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		p := NewPaginatorCtx([]DataSourceCtx[int]{&ctxSource{total: 10, block: true}}, 2)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := p.GetPageCtx(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("GetPageCtx() error = %v, want DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("adapter", func(t *testing.T) {
+		src := FromDataSource[int](&SliceSource[int]{data: []int{1, 2, 3}})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := src.Total(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("Total() error = %v, want Canceled", err)
+		}
+		if got, _ := src.GetChunk(context.Background(), 1, 5); !reflect.DeepEqual(got, []int{2, 3}) {
+			t.Errorf("GetChunk() = %v", got)
+		}
+	})
+}
+
+func TestPaginator_GetPageSized(t *testing.T) {
+	p := NewPaginator([]DataSource[int]{
+		NewSliceSource([]int{1, 2, 3}),
+		NewSliceSource([]int{4, 5, 6, 7}),
+	}, 2, WithPageBase[int](1))
+
+	tests := []struct {
+		page, size int
+		want       []int
+		totalPages int
+	}{
+		{1, 3, []int{1, 2, 3}, 3},
+		{3, 3, []int{7}, 3},
+		{2, 0, []int{3, 4}, 4}, // 使用默认页大小
+	}
+	for _, tt := range tests {
+		got, info, err := p.GetPageSizedCtx(context.Background(), tt.page, tt.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) || info.TotalPages != tt.totalPages {
+			t.Errorf("GetPageSizedCtx(%d, %d) = %v, %+v", tt.page, tt.size, got, info)
+		}
 	}
 }
@@ -0,0 +1,184 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+// NamedSource 带名字的数据源，错误信息中用名字代替下标
+type NamedSource[T any] struct {
+	DataSourceCtx[T]
+	name string
+}
+
+func Named[T any](name string, src DataSourceCtx[T]) *NamedSource[T] {
+	return &NamedSource[T]{DataSourceCtx: src, name: name}
+}
+
+func (n *NamedSource[T]) Name() string {
+	return n.name
+}
+
+// SourceError 标明出错的数据源，Paginator、InterleavePaginator 和 MergedPaginator 都用它报告数据源错误
+type SourceError struct {
+	Index int
+	Name  string // 未命名时为 "#下标"
+	Err   error
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("paginator: source %s: %v", e.Name, e.Err)
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// SourceErrors partial 模式下跳过的数据源及其错误，key 为数据源名
+type SourceErrors map[string]error
+
+// Names 跳过的数据源名，已排序
+func (e SourceErrors) Names() []string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (e SourceErrors) Error() string {
+	var sb strings.Builder
+	sb.WriteString("paginator: skipped failing sources:")
+	for _, name := range e.Names() {
+		sb.WriteString(" ")
+		sb.WriteString(e[name].Error())
+		sb.WriteString(";")
+	}
+	return sb.String()
+}
+
+func (e SourceErrors) Unwrap() []error {
+	ret := make([]error, 0, len(e))
+	for _, err := range e {
+		ret = append(ret, err)
+	}
+	return ret
+}
+
+// merge 合并两组跳过的数据源，返回新的 SourceErrors
+func (e SourceErrors) merge(other SourceErrors) SourceErrors {
+	if len(other) == 0 {
+		return e
+	}
+	ret := make(SourceErrors, len(e)+len(other))
+	for name, err := range e {
+		ret[name] = err
+	}
+	for name, err := range other {
+		ret[name] = err
+	}
+	return ret
+}
+
+// orNil 没有跳过的数据源时返回 nil，避免返回非 nil 的空 error
+func (e SourceErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// WithPartialResults 数据源出错时跳过该数据源，用其余数据源补齐页面，同时返回页面数据和 SourceErrors
+func WithPartialResults[T any]() optional.Op[Paginator[T]] {
+	return func(p *Paginator[T]) {
+		p.partial = true
+	}
+}
+
+// nameOf 数据源实现了 Name（如 Named）时使用其名字，否则为 "#下标"
+func nameOf(i int, src any) string {
+	if named, ok := src.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return "#" + strconv.Itoa(i)
+}
+
+func newSourceError(i int, src any, err error) *SourceError {
+	return &SourceError{Index: i, Name: nameOf(i, src), Err: err}
+}
+
+func (p *Paginator[T]) sourceName(i int) string {
+	return nameOf(i, p.sources[i])
+}
+
+func (p *Paginator[T]) sourceErr(i int, err error) error {
+	return newSourceError(i, p.sources[i], err)
+}
+
+// partialErr partial 模式下可以忽略的错误
+func (p *Paginator[T]) partialErr(err error) (SourceErrors, bool) {
+	var srcErrs SourceErrors
+	if p.partial && errors.As(err, &srcErrs) {
+		return srcErrs, true
+	}
+	return nil, false
+}
+
+// fetchRange 读取全局区间；partial 模式下失败的数据源按空处理并重新规划，直到没有新的失败
+func (p *Paginator[T]) fetchRange(ctx context.Context, totals []int, offset, limit int, srcErrs SourceErrors) ([]T, error) {
+	items, _, err := p.fetchPlanned(ctx, totals, srcErrs, func([]int) (int, int) { return offset, limit })
+	return items, err
+}
+
+// fetchPlanned 每次规划都由 span 按当前 totals 计算区间，返回数据及最终规划使用的 totals（失败的数据源为0），供 PageInfo 使用
+func (p *Paginator[T]) fetchPlanned(ctx context.Context, totals []int, srcErrs SourceErrors, span func(totals []int) (offset, limit int)) ([]T, []int, error) {
+	if !p.partial {
+		offset, limit := span(totals)
+		items, err := p.fetch(ctx, p.plan(totals, offset, limit))
+		return items, totals, err
+	}
+
+	totals = slices.Clone(totals)
+	errs := make(SourceErrors, len(srcErrs))
+	for name, err := range srcErrs {
+		errs[name] = err
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		offset, limit := span(totals)
+		segments := p.plan(totals, offset, limit)
+		chunks, chunkErrs, _ := p.fetchSegments(ctx, segments)
+
+		var failed bool
+		for i, err := range chunkErrs {
+			if err == nil {
+				continue
+			}
+			failed = true
+			totals[segments[i].src] = 0
+			errs[p.sourceName(segments[i].src)] = err
+		}
+		if failed {
+			continue
+		}
+
+		result := make([]T, 0, limit)
+		for _, chunk := range chunks {
+			result = append(result, chunk...)
+		}
+		if len(errs) == 0 {
+			return result, totals, nil
+		}
+		return result, totals, errs
+	}
+}
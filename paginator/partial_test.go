@@ -0,0 +1,76 @@
+package paginator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPaginator_PartialResults(t *testing.T) {
+	var (
+		errChunk = errors.New("chunk down")
+		errTotal = errors.New("count down")
+		sources  = []DataSourceCtx[int]{
+			Named[int]("cache", &ctxSource{total: 2}),
+			Named[int]("flaky", &ctxSource{total: 3, chunkErr: errChunk}),
+			&ctxSource{total: 4},
+			&ctxSource{totalErr: errTotal},
+		}
+	)
+
+	t.Run("strict", func(t *testing.T) {
+		p := NewPaginatorCtx(sources, 3)
+		_, err := p.GetPage(0)
+		var srcErr *SourceError
+		if !errors.As(err, &srcErr) || srcErr.Name != "#3" || !errors.Is(err, errTotal) {
+			t.Errorf("GetPage() error = %v", err)
+		}
+
+		p = NewPaginatorCtx(sources[:3], 3)
+		if _, err = p.GetPage(0); !errors.As(err, &srcErr) || srcErr.Name != "flaky" || srcErr.Index != 1 {
+			t.Errorf("GetPage() error = %v", err)
+		}
+	})
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("partial parallel=%v", parallel), func(t *testing.T) {
+			p := NewPaginatorCtx(sources, 3, WithPartialResults[int]())
+			if parallel {
+				WithParallelFetch[int]()(p)
+			}
+
+			page, info, err := p.GetPageInfo(0)
+			var srcErrs SourceErrors
+			if !errors.As(err, &srcErrs) {
+				t.Fatalf("GetPageInfo() error = %v, want SourceErrors", err)
+			}
+			// flaky 被跳过，由第三个数据源补齐
+			if !reflect.DeepEqual(page, []int{0, 1, 0}) {
+				t.Errorf("page = %v", page)
+			}
+			if len(srcErrs) != 2 || !errors.Is(srcErrs["flaky"], errChunk) || !errors.Is(srcErrs["#3"], errTotal) {
+				t.Errorf("SourceErrors = %v", srcErrs)
+			}
+			if !errors.Is(err, errChunk) || !strings.Contains(err.Error(), "flaky") {
+				t.Errorf("error = %v", err)
+			}
+			// 与页内容一致，不计入跳过的数据源
+			if info.TotalItems != 6 || info.TotalPages != 2 {
+				t.Errorf("TotalItems = %v", info.TotalItems)
+			}
+
+			if got, err := p.GetRange(0, 10); !reflect.DeepEqual(got, []int{0, 1, 0, 1, 2, 3}) || err == nil {
+				t.Errorf("GetRange() = %v, %v", got, err)
+			}
+		})
+	}
+
+	t.Run("all healthy", func(t *testing.T) {
+		p := NewPaginatorCtx([]DataSourceCtx[int]{Named[int]("a", &ctxSource{total: 2})}, 3, WithPartialResults[int]())
+		if page, err := p.GetPage(0); err != nil || len(page) != 2 {
+			t.Errorf("GetPage() = %v, %v", page, err)
+		}
+	})
+}
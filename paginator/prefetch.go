@@ -0,0 +1,98 @@
+package paginator
+
+import (
+	"context"
+
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+// prefetchPage 后台预取的一页，done 关闭后结果可读
+type prefetchPage[T any] struct {
+	pageNum int
+	done    chan struct{}
+	cancel  context.CancelFunc
+	taken   bool // 已被取走或丢弃，结束后释放槽位；受 pfMu 保护
+
+	items []T
+	info  PageInfo
+	err   error
+}
+
+// WithPrefetch GetPage(n) 返回后在后台预取第 n+1 页，请求其他页时取消并丢弃预取结果。
+// 同一时间最多一个预取在运行：被丢弃的预取不一定响应取消，结束前不会开始新的预取。
+// 快照模式下预取页的 PageInfo.Drift 为预取时的检查结果
+func WithPrefetch[T any]() optional.Op[Paginator[T]] {
+	return func(p *Paginator[T]) {
+		p.prefetch = true
+	}
+}
+
+func (p *Paginator[T]) getPageWithPrefetch(ctx context.Context, pageNum int) ([]T, PageInfo, error) {
+	if pf := p.takePrefetched(pageNum); pf != nil {
+		select {
+		case <-pf.done:
+			if pf.err == nil {
+				p.startPrefetch(pf.info)
+				return pf.items, pf.info, nil
+			}
+			// 预取失败时按正常流程重新获取
+		case <-ctx.Done():
+			pf.cancel()
+			return nil, PageInfo{}, ctx.Err()
+		}
+	}
+
+	items, info, err := p.loadPageInfo(ctx, pageNum)
+	if err == nil {
+		p.startPrefetch(info)
+	}
+	return items, info, err
+}
+
+// takePrefetched 取走第 pageNum 页的预取，其他页的预取取消后丢弃；进行中的预取结束前仍占用槽位
+func (p *Paginator[T]) takePrefetched(pageNum int) *prefetchPage[T] {
+	p.pfMu.Lock()
+	defer p.pfMu.Unlock()
+	pf := p.prefetched
+	if pf == nil || pf.taken {
+		return nil
+	}
+	pf.taken = true
+	select {
+	case <-pf.done:
+		p.prefetched = nil
+	default:
+	}
+	if pf.pageNum != pageNum {
+		pf.cancel()
+		return nil
+	}
+	return pf
+}
+
+func (p *Paginator[T]) startPrefetch(cur PageInfo) {
+	if !cur.HasNext {
+		return
+	}
+	p.pfMu.Lock()
+	defer p.pfMu.Unlock()
+	if p.prefetched != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pf := &prefetchPage[T]{pageNum: cur.Page + 1, done: make(chan struct{}), cancel: cancel}
+	p.prefetched = pf
+	go func() {
+		defer cancel()
+		items, info, err := p.loadPageInfo(ctx, pf.pageNum)
+
+		p.pfMu.Lock()
+		defer p.pfMu.Unlock()
+		pf.items, pf.info, pf.err = items, info, err
+		if pf.taken {
+			p.prefetched = nil
+		}
+		close(pf.done)
+	}()
+}
@@ -0,0 +1,185 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type slowSource struct {
+	ctxSource
+	chunks atomic.Int32
+	delay  time.Duration
+
+	mu      sync.Mutex
+	offsets map[int]int // 各 offset 被请求的次数
+}
+
+func (s *slowSource) GetChunk(ctx context.Context, offset, limit int) ([]int, error) {
+	s.chunks.Add(1)
+	s.mu.Lock()
+	if s.offsets == nil {
+		s.offsets = map[int]int{}
+	}
+	s.offsets[offset]++
+	s.mu.Unlock()
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return s.ctxSource.GetChunk(ctx, offset, limit)
+}
+
+func TestPaginator_Prefetch(t *testing.T) {
+	src := &slowSource{ctxSource: ctxSource{total: 10}, delay: 30 * time.Millisecond}
+	p := NewPaginatorCtx([]DataSourceCtx[int]{src}, 3, WithPrefetch[int]())
+
+	if page, _ := p.GetPage(0); !reflect.DeepEqual(page, []int{0, 1, 2}) {
+		t.Fatalf("page 0 = %v", page)
+	}
+	page, err := p.GetPage(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(page, []int{3, 4, 5}) {
+		t.Errorf("page 1 = %v", page)
+	}
+	// 第1页直接使用预取结果，不再请求数据源
+	src.mu.Lock()
+	if n := src.offsets[3]; n != 1 {
+		t.Errorf("offset 3 fetched %d times, want 1", n)
+	}
+	src.mu.Unlock()
+
+	// 请求其他页时丢弃预取（第2页的预取被取消）
+	if page, _ := p.GetPage(3); !reflect.DeepEqual(page, []int{9}) {
+		t.Errorf("page 3 = %v", page)
+	}
+	// 最后一页不预取
+	p.pfMu.Lock()
+	if p.prefetched != nil {
+		t.Errorf("unexpected prefetch of page %d", p.prefetched.pageNum)
+	}
+	p.pfMu.Unlock()
+}
+
+func TestPaginator_PrefetchConcurrent(t *testing.T) {
+	src := &slowSource{ctxSource: ctxSource{total: 100}, delay: time.Millisecond}
+	p := NewPaginatorCtx([]DataSourceCtx[int]{src}, 5, WithPrefetch[int]())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			page, err := p.GetPage(n % 4)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if page[0] != (n%4)*5 {
+				t.Errorf("page %d = %v", n%4, page)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// stuckSource 奇数页的 GetChunk 阻塞到 release 关闭，且不响应 ctx
+type stuckSource struct {
+	SliceSource[int]
+	pageSize int
+	entered  chan struct{}
+	release  chan struct{}
+
+	mu         sync.Mutex
+	stuck      int
+	maxStuck   int
+	stuckCalls int
+}
+
+func (s *stuckSource) GetChunk(offset, limit int) ([]int, error) {
+	if offset/s.pageSize%2 == 1 {
+		s.mu.Lock()
+		s.stuck++
+		s.stuckCalls++
+		s.maxStuck = max(s.maxStuck, s.stuck)
+		s.mu.Unlock()
+
+		s.entered <- struct{}{}
+		<-s.release
+
+		s.mu.Lock()
+		s.stuck--
+		s.mu.Unlock()
+	}
+	return s.SliceSource.GetChunk(offset, limit)
+}
+
+func waitPrefetch[T any](p *Paginator[T]) {
+	p.pfMu.Lock()
+	pf := p.prefetched
+	p.pfMu.Unlock()
+	if pf != nil {
+		<-pf.done
+	}
+}
+
+func TestPaginator_PrefetchAtMostOne(t *testing.T) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+	src := &stuckSource{
+		SliceSource: SliceSource[int]{data: data},
+		pageSize:    2,
+		entered:     make(chan struct{}, 10),
+		release:     make(chan struct{}),
+	}
+	p := NewPaginator([]DataSource[int]{src}, 2, WithPrefetch[int]())
+
+	// 第1页的预取卡住，请求其他页时取消也无法结束，不应再开始新的预取
+	for _, n := range []int{0, 10, 20} {
+		page, err := p.GetPage(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []int{n * 2, n*2 + 1}; !reflect.DeepEqual(page, want) {
+			t.Errorf("page %d = %v, want %v", n, page, want)
+		}
+		if n == 0 {
+			<-src.entered
+		}
+	}
+	src.mu.Lock()
+	if src.stuckCalls != 1 {
+		t.Errorf("stuck prefetch calls = %d, want 1", src.stuckCalls)
+	}
+	src.mu.Unlock()
+
+	close(src.release)
+	waitPrefetch(p)
+	p.pfMu.Lock()
+	if p.prefetched != nil {
+		t.Error("discarded prefetch still holds the slot")
+	}
+	p.pfMu.Unlock()
+
+	// 槽位释放后恢复预取
+	if _, err := p.GetPage(30); err != nil {
+		t.Fatal(err)
+	}
+	waitPrefetch(p)
+	if page, _ := p.GetPage(31); !reflect.DeepEqual(page, []int{62, 63}) {
+		t.Errorf("page 31 = %v", page)
+	}
+	src.mu.Lock()
+	if src.stuckCalls != 2 || src.maxStuck != 1 {
+		t.Errorf("stuck calls = %d, max concurrent = %d, want 2 and 1", src.stuckCalls, src.maxStuck)
+	}
+	src.mu.Unlock()
+}
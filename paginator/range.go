@@ -0,0 +1,88 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStopWalk fn 返回该错误时 Walk 提前结束且不返回错误
+var ErrStopWalk = errors.New("paginator: stop walk")
+
+// GetRange 读取拼接后全局区间 [offset, offset+limit) 的数据，不受 pageSize 和 WithReverse 影响
+func (p *Paginator[T]) GetRange(offset, limit int) ([]T, error) {
+	return p.GetRangeCtx(context.Background(), offset, limit)
+}
+
+func (p *Paginator[T]) GetRangeCtx(ctx context.Context, offset, limit int) ([]T, error) {
+	if offset < 0 || limit <= 0 {
+		return []T{}, nil
+	}
+	totals, err := p.getTotals(ctx)
+	srcErrs, partial := p.partialErr(err)
+	if err != nil && !partial {
+		return nil, err
+	}
+	totals, _, err = p.resolveTotals(ctx, totals, offset+limit)
+	probeErrs, partial := p.partialErr(err)
+	if err != nil && !partial {
+		return nil, err
+	}
+	return p.fetchRange(ctx, totals, offset, limit, srcErrs.merge(probeErrs))
+}
+
+// Walk 按 chunkSize 正序遍历所有数据源的全部元素（不受 WithReverse 影响）；fn 出错或 ctx 结束时停止，遍历期间使用开始时的 Total
+func (p *Paginator[T]) Walk(ctx context.Context, chunkSize int, fn func(chunk []T) error) error {
+	if chunkSize <= 0 {
+		chunkSize = p.pageSize
+	}
+	totals, err := p.getTotals(ctx)
+	if err != nil {
+		return err
+	}
+
+	for offset := 0; ; offset += chunkSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		resolved, _, err := p.resolveTotals(ctx, totals, offset+chunkSize)
+		if err != nil {
+			return err
+		}
+		segments := p.plan(resolved, offset, chunkSize)
+		if len(segments) == 0 {
+			return nil
+		}
+		chunk, err := p.fetch(ctx, segments)
+		if err != nil {
+			return err
+		}
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := fn(chunk); err != nil {
+			if errors.Is(err, ErrStopWalk) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Total 所有数据源的元素总数，有未知总数的数据源时会探测到底；partial 模式下同时返回失败数据源的 SourceErrors
+func (p *Paginator[T]) Total(ctx context.Context) (int, error) {
+	totals, err := p.getTotals(ctx)
+	srcErrs, partial := p.partialErr(err)
+	if err != nil && !partial {
+		return 0, err
+	}
+	totals, resolveErr := p.resolveAll(ctx, totals)
+	probeErrs, partial := p.partialErr(resolveErr)
+	if resolveErr != nil && !partial {
+		return 0, resolveErr
+	}
+	var sum int
+	for _, total := range totals {
+		sum += total
+	}
+	return sum, srcErrs.merge(probeErrs).orNil()
+}
@@ -0,0 +1,77 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPaginator_GetRange(t *testing.T) {
+	p := NewPaginator([]DataSource[int]{
+		NewSliceSource([]int{1, 2, 3}),
+		NewSliceSource([]int{}),
+		NewSliceSource([]int{4, 5, 6, 7}),
+	}, 2)
+
+	tests := []struct {
+		offset, limit int
+		want          []int
+	}{
+		{0, 7, []int{1, 2, 3, 4, 5, 6, 7}},
+		{2, 3, []int{3, 4, 5}},
+		{5, 10, []int{6, 7}},
+		{7, 1, []int{}},
+		{-1, 1, []int{}},
+	}
+	for _, tt := range tests {
+		got, err := p.GetRange(tt.offset, tt.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GetRange(%d, %d) = %v, want %v", tt.offset, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestPaginator_Walk(t *testing.T) {
+	p := NewPaginator([]DataSource[int]{
+		NewSliceSource([]int{1, 2, 3}),
+		NewSliceSource([]int{4, 5, 6, 7}),
+	}, 2)
+
+	var chunks [][]int
+	err := p.Walk(context.Background(), 3, func(chunk []int) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}; !reflect.DeepEqual(chunks, want) {
+		t.Errorf("chunks = %v, want %v", chunks, want)
+	}
+
+	var cnt int
+	errBoom := errors.New("boom")
+	if err := p.Walk(context.Background(), 2, func([]int) error {
+		cnt++
+		return errBoom
+	}); !errors.Is(err, errBoom) || cnt != 1 {
+		t.Errorf("Walk() error = %v, calls = %d", err, cnt)
+	}
+	if err := p.Walk(context.Background(), 2, func([]int) error { return ErrStopWalk }); err != nil {
+		t.Errorf("Walk() error = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cnt = 0
+	if err := p.Walk(ctx, 1, func([]int) error {
+		cnt++
+		cancel()
+		return nil
+	}); !errors.Is(err, context.Canceled) || cnt != 1 {
+		t.Errorf("Walk() error = %v, calls = %d", err, cnt)
+	}
+}
@@ -0,0 +1,51 @@
+package paginator
+
+import (
+	"context"
+
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+// WithReverse 倒序分页：把所有数据源拼接后从末尾开始，第一页是最后 pageSize 个元素且逆序返回；
+// 总数不是 pageSize 整数倍时，最后一页（最早的数据）不满。
+// 只影响按页码取页的 GetPage*、GetPageSizedCtx、LastPage（及基于它们的 httppage 页码模式），
+// GetRange、Walk 和令牌分页 FirstPage/NextPage 始终按正序
+func WithReverse[T any]() optional.Op[Paginator[T]] {
+	return func(p *Paginator[T]) {
+		p.reverse = true
+	}
+}
+
+// pageRange 第 idx 页（从0计）在拼接后数据中的正序区间
+func (p *Paginator[T]) pageRange(totals []int, idx, pageSize int) (offset, limit int) {
+	if !p.reverse {
+		return idx * pageSize, pageSize
+	}
+	var sum int
+	for _, total := range totals {
+		sum += total
+	}
+	end := sum - idx*pageSize
+	if end <= 0 {
+		return sum, 0
+	}
+	start := max(end-pageSize, 0)
+	return start, end - start
+}
+
+// LastPage 按当前方向的最后一页，倒序模式下即最早的数据
+func (p *Paginator[T]) LastPage() ([]T, PageInfo, error) {
+	return p.LastPageCtx(context.Background())
+}
+
+func (p *Paginator[T]) LastPageCtx(ctx context.Context) ([]T, PageInfo, error) {
+	total, err := p.Total(ctx)
+	if _, partial := p.partialErr(err); err != nil && !partial {
+		return nil, PageInfo{}, err
+	}
+	lastIdx := 0
+	if p.pageSize > 0 && total > 0 {
+		lastIdx = (total - 1) / p.pageSize
+	}
+	return p.GetPageInfoCtx(ctx, lastIdx+p.pageBase)
+}
@@ -0,0 +1,79 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestPaginator_Reverse(t *testing.T) {
+	p := NewPaginator([]DataSource[int]{
+		NewSliceSource([]int{1, 2, 3}),
+		NewSliceSource([]int{4, 5, 6, 7}),
+	}, 3, WithReverse[int](), WithPageBase[int](1))
+
+	tests := []struct {
+		page int
+		want []int
+	}{
+		{1, []int{7, 6, 5}},
+		{2, []int{4, 3, 2}},
+		{3, []int{1}},
+		{4, []int{}},
+	}
+	for _, tt := range tests {
+		got, err := p.GetPage(tt.page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GetPage(%d) = %v, want %v", tt.page, got, tt.want)
+		}
+	}
+
+	items, info, err := p.LastPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(items, []int{1}) || info.Page != 3 || info.HasNext || !info.HasPrev {
+		t.Errorf("LastPage() = %v, %+v", items, info)
+	}
+
+	forward := NewPaginator([]DataSource[int]{NewSliceSource([]int{1, 2, 3, 4, 5, 6, 7})}, 3)
+	if items, info, _ := forward.LastPage(); !reflect.DeepEqual(items, []int{7}) || info.Page != 2 {
+		t.Errorf("forward LastPage() = %v, %+v", items, info)
+	}
+	empty := NewPaginator([]DataSource[int]{NewSliceSource([]int{})}, 3, WithReverse[int]())
+	if items, _, err := empty.LastPage(); err != nil || len(items) != 0 {
+		t.Errorf("empty LastPage() = %v, %v", items, err)
+	}
+}
+
+// 倒序只作用于按页码取页，区间读取、遍历和令牌分页仍按正序
+func TestPaginator_ReverseScope(t *testing.T) {
+	p := NewPaginator([]DataSource[int]{
+		NewSliceSource([]int{1, 2, 3}),
+		NewSliceSource([]int{4, 5}),
+	}, 2, WithReverse[int](), WithTokenKey[int]([]byte("k"), 0))
+
+	if got, _ := p.GetRange(1, 3); !reflect.DeepEqual(got, []int{2, 3, 4}) {
+		t.Errorf("GetRange() = %v", got)
+	}
+
+	var walked []int
+	_ = p.Walk(context.Background(), 2, func(chunk []int) error {
+		walked = append(walked, chunk...)
+		return nil
+	})
+	if !reflect.DeepEqual(walked, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Walk() = %v", walked)
+	}
+
+	if got, _, _ := p.FirstPage(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("FirstPage() = %v", got)
+	}
+
+	if got, _, _ := p.GetPageSizedCtx(context.Background(), 0, 3); !reflect.DeepEqual(got, []int{5, 4, 3}) {
+		t.Errorf("GetPageSizedCtx() = %v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package paginator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+// DriftError 数据源当前 Total 与快照不一致，分页仍按 Snapshot 进行
+type DriftError struct {
+	Snapshot []int `json:"snapshot"`
+	Current  []int `json:"current"`
+}
+
+func (e *DriftError) Error() string {
+	return fmt.Sprintf("paginator: source totals drifted from snapshot %v to %v", e.Snapshot, e.Current)
+}
+
+// Sources 发生变化的数据源下标
+func (e *DriftError) Sources() []int {
+	var ret []int
+	for i := range e.Snapshot {
+		if i >= len(e.Current) || e.Snapshot[i] != e.Current[i] {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+// WithSnapshot 第一次请求时记录各数据源的 Total，之后所有页都在该快照内计算，只有调用 Refresh 才重新记录（不受 WithTotalsCache 的 ttl 影响）；
+// 每次取页会检查当前 Total，不一致时在 PageInfo.Drift 中给出，不作为错误返回
+func WithSnapshot[T any]() optional.Op[Paginator[T]] {
+	return func(p *Paginator[T]) {
+		p.snapshot = true
+	}
+}
+
+// Snapshot 当前使用的各数据源 Total
+func (p *Paginator[T]) Snapshot(ctx context.Context) ([]int, error) {
+	totals, err := p.getTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(totals), nil
+}
+
+// CheckDrift 对比快照与当前 Total，一致时返回 nil，不一致时返回 *DriftError
+func (p *Paginator[T]) CheckDrift(ctx context.Context) error {
+	drift, err := p.checkDrift(ctx)
+	if err != nil {
+		return err
+	}
+	if drift != nil {
+		return drift
+	}
+	return nil
+}
+
+// checkDrift 一致时返回 nil, nil
+func (p *Paginator[T]) checkDrift(ctx context.Context) (*DriftError, error) {
+	snapshot, err := p.getTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := p.loadTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Equal(snapshot, current) {
+		return nil, nil
+	}
+	return &DriftError{Snapshot: slices.Clone(snapshot), Current: current}, nil
+}
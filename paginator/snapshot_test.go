@@ -0,0 +1,114 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPaginator_Snapshot(t *testing.T) {
+	var (
+		src1 = NewSliceSource([]int{1, 2, 3})
+		src2 = NewSliceSource([]int{4, 5, 6, 7})
+		p    = NewPaginator([]DataSource[int]{src1, src2}, 3, WithSnapshot[int]())
+	)
+
+	if page, info, err := p.GetPageInfo(0); err != nil || !reflect.DeepEqual(page, []int{1, 2, 3}) || info.Drift != nil {
+		t.Fatalf("page 0 = %v, %+v, %v", page, info, err)
+	}
+
+	// 第一个数据源新增一条，快照内第1页仍从第二个数据源开始，漂移只在 PageInfo 中提示
+	src1.data = append(src1.data, 100)
+	page, info, err := p.GetPageInfo(1)
+	if err != nil {
+		t.Fatalf("GetPageInfo() error = %v, want nil on drift", err)
+	}
+	if !reflect.DeepEqual(page, []int{4, 5, 6}) {
+		t.Errorf("page 1 = %v", page)
+	}
+	drift := info.Drift
+	if drift == nil || !reflect.DeepEqual(drift.Sources(), []int{0}) || !reflect.DeepEqual(drift.Current, []int{4, 4}) {
+		t.Errorf("drift = %+v", drift)
+	}
+	var driftErr *DriftError
+	if err := p.CheckDrift(context.Background()); !errors.As(err, &driftErr) {
+		t.Errorf("CheckDrift() = %v, want DriftError", err)
+	}
+	_, info, _ = p.GetPageInfo(2)
+	if info.TotalItems != 7 {
+		t.Errorf("TotalItems = %v, want snapshot 7", info.TotalItems)
+	}
+
+	p.Refresh()
+	page, info, err = p.GetPageInfo(1)
+	if err != nil || !reflect.DeepEqual(page, []int{100, 4, 5}) || info.Drift != nil {
+		t.Errorf("after Refresh page 1 = %v, %+v, %v", page, info, err)
+	}
+	if snap, _ := p.Snapshot(context.Background()); !reflect.DeepEqual(snap, []int{4, 4}) {
+		t.Errorf("Snapshot() = %v", snap)
+	}
+	if err := p.CheckDrift(context.Background()); err != nil {
+		t.Errorf("CheckDrift() = %v, want nil", err)
+	}
+}
+
+func TestPaginator_SnapshotIgnoresTotalsTTL(t *testing.T) {
+	var (
+		src = NewSliceSource([]int{1, 2, 3})
+		now = time.Unix(0, 0)
+	)
+	// 选项顺序不影响快照
+	for _, ops := range [][]func(*Paginator[int]){
+		{WithSnapshot[int](), WithTotalsCache[int](time.Second)},
+		{WithTotalsCache[int](time.Second), WithSnapshot[int]()},
+	} {
+		src.data = []int{1, 2, 3}
+		p := NewPaginator([]DataSource[int]{src}, 10)
+		for _, op := range ops {
+			op(p)
+		}
+		p.now = func() time.Time { return now }
+
+		if _, info, _ := p.GetPageInfo(0); info.TotalItems != 3 {
+			t.Fatalf("TotalItems = %v", info.TotalItems)
+		}
+		src.data = append(src.data, 4)
+		now = now.Add(time.Minute)
+		if _, info, _ := p.GetPageInfo(0); info.TotalItems != 3 || info.Drift == nil {
+			t.Errorf("after ttl TotalItems = %v, drift = %v, want snapshot kept", info.TotalItems, info.Drift)
+		}
+	}
+}
+
+func TestPaginator_SnapshotPrefetch(t *testing.T) {
+	src := NewSliceSource([]int{1, 2, 3, 4, 5, 6})
+	p := NewPaginator([]DataSource[int]{src}, 2, WithSnapshot[int](), WithPrefetch[int]())
+	waitPrefetch := func(pageNum int) {
+		p.pfMu.Lock()
+		pf := p.prefetched
+		p.pfMu.Unlock()
+		if pf == nil || pf.pageNum != pageNum {
+			t.Fatalf("page %d not prefetched", pageNum)
+		}
+		<-pf.done
+	}
+
+	if _, err := p.GetPage(0); err != nil {
+		t.Fatal(err)
+	}
+	waitPrefetch(1)
+	src.data = append(src.data, 7)
+
+	// 漂移后预取仍然继续
+	page, info, err := p.GetPageInfo(1)
+	if err != nil || !reflect.DeepEqual(page, []int{3, 4}) {
+		t.Fatalf("page 1 = %v, %v", page, err)
+	}
+	waitPrefetch(2)
+	page, info, err = p.GetPageInfo(2)
+	if err != nil || info.Drift == nil || !reflect.DeepEqual(page, []int{5, 6}) {
+		t.Errorf("page 2 = %v, %+v, %v", page, info, err)
+	}
+}
@@ -0,0 +1,99 @@
+package paginator
+
+import (
+	"sync"
+)
+
+// SliceSource 基于内存切片的数据源
+type SliceSource[T any] struct {
+	data []T
+}
+
+func NewSliceSource[T any](data []T) *SliceSource[T] {
+	return &SliceSource[T]{data: data}
+}
+
+func (s *SliceSource[T]) GetChunk(offset, limit int) ([]T, error) {
+	if offset >= len(s.data) || limit <= 0 {
+		return []T{}, nil
+	}
+	end := min(offset+limit, len(s.data))
+	return s.data[offset:end], nil
+}
+
+func (s *SliceSource[T]) Total() int {
+	return len(s.data)
+}
+
+// FuncSource 由一对函数组成的数据源
+type FuncSource[T any] struct {
+	ChunkFunc func(offset, limit int) ([]T, error)
+	TotalFunc func() int
+}
+
+func NewFuncSource[T any](chunk func(offset, limit int) ([]T, error), total func() int) *FuncSource[T] {
+	return &FuncSource[T]{ChunkFunc: chunk, TotalFunc: total}
+}
+
+func (f *FuncSource[T]) GetChunk(offset, limit int) ([]T, error) {
+	return f.ChunkFunc(offset, limit)
+}
+
+func (f *FuncSource[T]) Total() int {
+	return f.TotalFunc()
+}
+
+// StreamSource 按需从 channel 或迭代函数读取并缓存，只能向后读取的流也能随机分页；
+// 流读完之前 Total 返回 UnknownTotal，由 Paginator 按需探测，不会为了总数读完整个流
+type StreamSource[T any] struct {
+	next func() (T, bool)
+
+	mu   sync.Mutex
+	buf  []T
+	done bool
+}
+
+// NewChanSource ch 关闭表示流结束
+func NewChanSource[T any](ch <-chan T) *StreamSource[T] {
+	return NewIterSource(func() (T, bool) {
+		t, ok := <-ch
+		return t, ok
+	})
+}
+
+// NewIterSource next 返回 false 表示流结束
+func NewIterSource[T any](next func() (T, bool)) *StreamSource[T] {
+	return &StreamSource[T]{next: next}
+}
+
+func (s *StreamSource[T]) GetChunk(offset, limit int) ([]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fill(offset + limit)
+	if offset >= len(s.buf) || limit <= 0 {
+		return []T{}, nil
+	}
+	end := min(offset+limit, len(s.buf))
+	return s.buf[offset:end:end], nil
+}
+
+func (s *StreamSource[T]) Total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.done {
+		return UnknownTotal
+	}
+	return len(s.buf)
+}
+
+// fill 读取直到缓存 n 个元素或流结束
+func (s *StreamSource[T]) fill(n int) {
+	for !s.done && len(s.buf) < n {
+		t, ok := s.next()
+		if !ok {
+			s.done = true
+			break
+		}
+		s.buf = append(s.buf, t)
+	}
+}
@@ -0,0 +1,60 @@
+package paginator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SQLSource database/sql 数据源：countQuery 返回一行一列的总数；
+// pageQuery 以 LIMIT/OFFSET 分页，limit 和 offset 依次追加在 args 之后作为最后两个参数，
+// 例如 "SELECT id, name FROM users WHERE age > ? ORDER BY id LIMIT ? OFFSET ?"
+type SQLSource[T any] struct {
+	db         *sql.DB
+	countQuery string
+	pageQuery  string
+	args       []any
+	scan       func(*sql.Rows) (T, error)
+}
+
+func NewSQLSource[T any](db *sql.DB, countQuery, pageQuery string, scan func(*sql.Rows) (T, error), args ...any) *SQLSource[T] {
+	return &SQLSource[T]{
+		db:         db,
+		countQuery: countQuery,
+		pageQuery:  pageQuery,
+		args:       args,
+		scan:       scan,
+	}
+}
+
+func (s *SQLSource[T]) Total(ctx context.Context) (int, error) {
+	var total int
+	if err := s.db.QueryRowContext(ctx, s.countQuery, s.args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("count query: %w", err)
+	}
+	return total, nil
+}
+
+func (s *SQLSource[T]) GetChunk(ctx context.Context, offset int, limit int) ([]T, error) {
+	args := make([]any, 0, len(s.args)+2)
+	args = append(append(args, s.args...), limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, s.pageQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("page query: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]T, 0, limit)
+	for rows.Next() {
+		t, err := s.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("page query: %w", err)
+	}
+	return result, nil
+}
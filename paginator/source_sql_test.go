@@ -0,0 +1,117 @@
+package paginator
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var registerFakeDriver sync.Once
+
+// fakeDriver 进程内的 database/sql 驱动：COUNT 查询返回行数，其余查询按最后两个参数 LIMIT/OFFSET 返回 (id, name)
+type fakeDriver struct {
+	names []string
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+type fakeRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if strings.HasPrefix(s.query, "SELECT COUNT") {
+		return &fakeRows{cols: []string{"count"}, rows: [][]driver.Value{{int64(len(s.d.names))}}}, nil
+	}
+	if len(args) < 2 {
+		return nil, errors.New("missing limit/offset")
+	}
+	limit, offset := int(args[len(args)-2].(int64)), int(args[len(args)-1].(int64))
+	ret := &fakeRows{cols: []string{"id", "name"}}
+	for i := offset; i < min(offset+limit, len(s.d.names)); i++ {
+		ret.rows = append(ret.rows, []driver.Value{int64(i + 1), s.d.names[i]})
+	}
+	return ret, nil
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type user struct {
+	ID   int64
+	Name string
+}
+
+func TestSQLSource(t *testing.T) {
+	registerFakeDriver.Do(func() {
+		sql.Register("paginator-fake", &fakeDriver{names: []string{"a", "b", "c", "d", "e"}})
+	})
+	db, err := sql.Open("paginator-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	src := NewSQLSource(db,
+		"SELECT COUNT(*) FROM users",
+		"SELECT id, name FROM users ORDER BY id LIMIT ? OFFSET ?",
+		func(rows *sql.Rows) (u user, err error) {
+			err = rows.Scan(&u.ID, &u.Name)
+			return u, err
+		},
+	)
+	p := NewPaginatorCtx([]DataSourceCtx[user]{src}, 2)
+
+	items, info, err := p.GetPageInfoCtx(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []user{{3, "c"}, {4, "d"}}; !reflect.DeepEqual(items, want) {
+		t.Errorf("page 1 = %v, want %v", items, want)
+	}
+	if info.TotalItems != 5 || info.TotalPages != 3 {
+		t.Errorf("info = %+v", info)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := src.GetChunk(ctx, 0, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetChunk() error = %v, want Canceled", err)
+	}
+}
@@ -0,0 +1,108 @@
+package paginator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSources(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	newChan := func() *StreamSource[int] {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for _, v := range data {
+				ch <- v
+			}
+		}()
+		return NewChanSource(ch)
+	}
+	newIter := func() *StreamSource[int] {
+		var i int
+		return NewIterSource(func() (int, bool) {
+			if i >= len(data) {
+				return 0, false
+			}
+			i++
+			return data[i-1], true
+		})
+	}
+
+	tests := []struct {
+		name string
+		src  DataSource[int]
+	}{
+		{"slice", NewSliceSource(data)},
+		{"func", NewFuncSource(NewSliceSource(data).GetChunk, func() int { return len(data) })},
+		{"chan", newChan()},
+		{"iter", newIter()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.src.GetChunk(2, 3); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+				t.Errorf("GetChunk(2, 3) = %v", got)
+			}
+			if got, _ := tt.src.GetChunk(0, 2); !reflect.DeepEqual(got, []int{1, 2}) {
+				t.Errorf("GetChunk(0, 2) = %v", got)
+			}
+			if got, _ := tt.src.GetChunk(6, 5); !reflect.DeepEqual(got, []int{7}) {
+				t.Errorf("GetChunk(6, 5) = %v", got)
+			}
+			if got, _ := tt.src.GetChunk(10, 5); len(got) != 0 {
+				t.Errorf("GetChunk(10, 5) = %v", got)
+			}
+			if tt.src.Total() != len(data) {
+				t.Errorf("Total() = %v", tt.src.Total())
+			}
+		})
+	}
+}
+
+func TestStreamSource_Lazy(t *testing.T) {
+	var pulled int
+	src := NewIterSource(func() (int, bool) {
+		pulled++
+		return pulled, true // 无限流
+	})
+	if got, _ := src.GetChunk(5, 5); !reflect.DeepEqual(got, []int{6, 7, 8, 9, 10}) {
+		t.Errorf("GetChunk(5, 5) = %v", got)
+	}
+	if pulled != 10 {
+		t.Errorf("pulled %d items, want 10", pulled)
+	}
+	if total := src.Total(); total != UnknownTotal {
+		t.Errorf("Total() = %v before stream ends, want UnknownTotal", total)
+	}
+}
+
+func TestStreamSource_LazyPaginator(t *testing.T) {
+	var pulled int
+	iter := NewIterSource(func() (int, bool) {
+		if pulled >= 100000 {
+			return 0, false
+		}
+		pulled++
+		return pulled, true
+	})
+	p := NewPaginator([]DataSource[int]{iter}, 2)
+	page, info, err := p.GetPageInfo(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(page, []int{1, 2}) || !info.Estimated || !info.HasNext {
+		t.Errorf("page 0 = %v, info = %+v", page, info)
+	}
+	if pulled != 2 {
+		t.Errorf("pulled %d items, want 2", pulled)
+	}
+
+	// 未关闭的 channel 也能分页，不会阻塞在 Total 上
+	ch := make(chan int, 10)
+	for i := 1; i <= 10; i++ {
+		ch <- i
+	}
+	p = NewPaginator([]DataSource[int]{NewChanSource(ch)}, 2)
+	if page, err := p.GetPage(1); err != nil || !reflect.DeepEqual(page, []int{3, 4}) {
+		t.Errorf("chan page 1 = %v, %v", page, err)
+	}
+}
@@ -0,0 +1,153 @@
+package paginator
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/1298509345/go-utils-frequently/optional"
+)
+
+var (
+	ErrInvalidToken  = errors.New("paginator: invalid page token")
+	ErrTokenExpired  = errors.New("paginator: page token expired")
+	ErrTokenKeyUnset = errors.New("paginator: page token key not configured")
+)
+
+// pageToken 令牌内容：下一页在第 Src 个数据源的 Offset 处开始，Totals 为首页时各数据源 Total 的快照
+type pageToken struct {
+	Src      int   `json:"s"`
+	Offset   int   `json:"o"`
+	PageSize int   `json:"n"`
+	Totals   []int `json:"t"`
+	Expire   int64 `json:"e,omitempty"` // unix 秒，0 表示不过期
+}
+
+// WithTokenKey 设置签名 key，ttl<=0 时令牌不过期
+func WithTokenKey[T any](key []byte, ttl time.Duration) optional.Op[Paginator[T]] {
+	return func(p *Paginator[T]) {
+		p.tokenKey = append([]byte(nil), key...)
+		p.tokenTTL = ttl
+	}
+}
+
+// FirstPage 返回第一页及下一页的令牌，没有下一页时令牌为空；令牌分页始终按正序，不受 WithReverse 影响
+func (p *Paginator[T]) FirstPage() ([]T, string, error) {
+	return p.FirstPageCtx(context.Background())
+}
+
+func (p *Paginator[T]) FirstPageCtx(ctx context.Context) ([]T, string, error) {
+	if len(p.tokenKey) == 0 {
+		return nil, "", ErrTokenKeyUnset
+	}
+	totals, err := p.getTotals(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if totals, err = p.resolveAll(ctx, totals); err != nil {
+		return nil, "", err
+	}
+	return p.pageAt(ctx, pageToken{PageSize: p.pageSize, Totals: totals})
+}
+
+// NextPage 按令牌继续翻页，令牌被篡改返回 ErrInvalidToken，过期返回 ErrTokenExpired
+func (p *Paginator[T]) NextPage(token string) ([]T, string, error) {
+	return p.NextPageCtx(context.Background(), token)
+}
+
+func (p *Paginator[T]) NextPageCtx(ctx context.Context, token string) ([]T, string, error) {
+	tok, err := p.decodeToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+	return p.pageAt(ctx, tok)
+}
+
+// pageAt 在令牌的 Total 快照内取一页，保证翻页期间数据源增删不会导致重复或遗漏定位
+func (p *Paginator[T]) pageAt(ctx context.Context, tok pageToken) ([]T, string, error) {
+	var offset, sum int
+	for i, total := range tok.Totals {
+		if i < tok.Src {
+			offset += total
+		}
+		sum += total
+	}
+	offset += tok.Offset
+
+	segments := p.plan(tok.Totals, offset, tok.PageSize)
+	items, err := p.fetch(ctx, segments)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, seg := range segments {
+		offset += seg.limit
+	}
+	if offset >= sum {
+		return items, "", nil
+	}
+
+	next := pageToken{PageSize: tok.PageSize, Totals: tok.Totals, Offset: offset}
+	for next.Src < len(next.Totals) && next.Offset >= next.Totals[next.Src] {
+		next.Offset -= next.Totals[next.Src]
+		next.Src++
+	}
+	nextToken, err := p.encodeToken(next)
+	if err != nil {
+		return nil, "", err
+	}
+	return items, nextToken, nil
+}
+
+func (p *Paginator[T]) encodeToken(tok pageToken) (string, error) {
+	if len(p.tokenKey) == 0 {
+		return "", ErrTokenKeyUnset
+	}
+	if p.tokenTTL > 0 {
+		tok.Expire = p.now().Add(p.tokenTTL).Unix()
+	}
+	payload, err := json.Marshal(tok)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(p.sign(payload)), nil
+}
+
+func (p *Paginator[T]) decodeToken(token string) (pageToken, error) {
+	var tok pageToken
+	if len(p.tokenKey) == 0 {
+		return tok, ErrTokenKeyUnset
+	}
+	encPayload, encSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return tok, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encPayload)
+	if err != nil {
+		return tok, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encSig)
+	if err != nil || !hmac.Equal(sig, p.sign(payload)) {
+		return tok, ErrInvalidToken
+	}
+	if err := json.Unmarshal(payload, &tok); err != nil {
+		return tok, ErrInvalidToken
+	}
+	if tok.Expire > 0 && p.now().Unix() >= tok.Expire {
+		return tok, ErrTokenExpired
+	}
+	if tok.PageSize <= 0 || tok.Src < 0 || tok.Offset < 0 || len(tok.Totals) != len(p.sources) {
+		return tok, ErrInvalidToken
+	}
+	return tok, nil
+}
+
+func (p *Paginator[T]) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.tokenKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,73 @@
+package paginator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPaginator_Token(t *testing.T) {
+	var (
+		src1 = &SliceSource[int]{data: []int{1, 2, 3}}
+		src2 = &SliceSource[int]{data: []int{4, 5, 6, 7}}
+		now  = time.Unix(1000, 0)
+		p    = NewPaginator([]DataSource[int]{src1, src2}, 3, WithTokenKey[int]([]byte("secret"), time.Minute))
+	)
+	p.now = func() time.Time { return now }
+
+	items, token, err := p.FirstPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := items
+	for token != "" {
+		items, token, err = p.NextPage(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, items...)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7}; !reflect.DeepEqual(all, want) {
+		t.Errorf("pages = %v, want %v", all, want)
+	}
+
+	// 令牌记录的是各数据源内的位置和 Total 快照，首页之后数据源变化不影响后续页的定位
+	_, token, _ = p.FirstPage()
+	src1.data = append(src1.data, 100)
+	if items, _, _ := p.NextPage(token); !reflect.DeepEqual(items, []int{4, 5, 6}) {
+		t.Errorf("NextPage() after insert = %v", items)
+	}
+	src1.data = src1.data[:3]
+
+	t.Run("tampered", func(t *testing.T) {
+		_, token, _ := p.FirstPage()
+		payload, sig, _ := strings.Cut(token, ".")
+		forged := []byte(payload)
+		forged[len(forged)/2] ^= 1
+		for _, bad := range []string{"", "abc", string(forged) + "." + sig, payload + ".AAAA"} {
+			if _, _, err := p.NextPage(bad); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("NextPage(%q) error = %v, want ErrInvalidToken", bad, err)
+			}
+		}
+		other := NewPaginator([]DataSource[int]{src1, src2}, 3, WithTokenKey[int]([]byte("other"), 0))
+		if _, _, err := other.NextPage(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("NextPage() with other key error = %v, want ErrInvalidToken", err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, token, _ := p.FirstPage()
+		now = now.Add(time.Minute)
+		if _, _, err := p.NextPage(token); !errors.Is(err, ErrTokenExpired) {
+			t.Errorf("NextPage() error = %v, want ErrTokenExpired", err)
+		}
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		if _, _, err := NewPaginator([]DataSource[int]{src1}, 3).FirstPage(); !errors.Is(err, ErrTokenKeyUnset) {
+			t.Errorf("FirstPage() error = %v, want ErrTokenKeyUnset", err)
+		}
+	})
+}
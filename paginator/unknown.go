@@ -0,0 +1,121 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+)
+
+// UnknownTotal 数据源无法低成本给出总数时 Total 返回该值，分页时顺序探测其大小
+const UnknownTotal = -1
+
+// ErrUnknownTotal 需要精确总数的分页方式（如 InterleavePaginator）遇到 UnknownTotal 时返回
+var ErrUnknownTotal = errors.New("paginator: source total unknown")
+
+// resolveTotals 把未知总数替换为探测到的大小：按顺序探测覆盖到全局位置 need 为止，
+// 遇到短块即确定该数据源的大小并记住；未探测完的数据源以已知下界代替，estimated 为 true。
+// partial 模式下探测失败的数据源记为0，并返回 SourceErrors
+func (p *Paginator[T]) resolveTotals(ctx context.Context, totals []int, need int) ([]int, bool, error) {
+	var hasUnknown bool
+	for _, total := range totals {
+		if total < 0 {
+			hasUnknown = true
+			break
+		}
+	}
+	if !hasUnknown {
+		return totals, false, nil
+	}
+
+	// 探测期间不持有 sizeMu，一个慢探测不会阻塞其他请求；结束后把探测结果合并回去
+	lower, exact, gen := p.loadSizes(len(totals))
+	defer p.storeSizes(lower, exact, gen)
+
+	var (
+		acc      int
+		reached  bool
+		resolved = make([]int, len(totals))
+		skipped  = make([]bool, len(totals))
+		srcErrs  = SourceErrors{}
+	)
+	for i, total := range totals {
+		if total >= 0 {
+			resolved[i] = total
+			acc += total
+			continue
+		}
+		for !reached && !exact[i] && acc+lower[i] < need {
+			chunk, err := p.sources[i].GetChunk(ctx, lower[i], p.probeSize())
+			if err != nil && !p.partial {
+				return nil, false, p.sourceErr(i, err)
+			}
+			if err != nil {
+				skipped[i] = true
+				srcErrs[p.sourceName(i)] = p.sourceErr(i, err)
+				break
+			}
+			lower[i] += len(chunk)
+			exact[i] = len(chunk) < p.probeSize()
+		}
+		if skipped[i] {
+			continue
+		}
+		resolved[i] = lower[i]
+		acc += lower[i]
+		if !exact[i] {
+			reached = true // 区间在该数据源内结束，后面的未知数据源暂不探测
+		}
+	}
+
+	var estimated bool
+	for i, total := range totals {
+		if total < 0 && !exact[i] && !skipped[i] {
+			estimated = true
+		}
+	}
+	if len(srcErrs) > 0 {
+		return resolved, estimated, srcErrs
+	}
+	return resolved, estimated, nil
+}
+
+// resolveAll 探测所有未知数据源的大小
+func (p *Paginator[T]) resolveAll(ctx context.Context, totals []int) ([]int, error) {
+	resolved, _, err := p.resolveTotals(ctx, totals, math.MaxInt)
+	return resolved, err
+}
+
+func (p *Paginator[T]) probeSize() int {
+	return max(p.pageSize, 1)
+}
+
+// loadSizes 已探测大小的副本，gen 用于识别期间是否调用过 Refresh
+func (p *Paginator[T]) loadSizes(n int) (lower []int, exact []bool, gen int) {
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	if len(p.lower) != n {
+		p.lower, p.exact = make([]int, n), make([]bool, n)
+	}
+	return slices.Clone(p.lower), slices.Clone(p.exact), p.sizeGen
+}
+
+// storeSizes 合并探测结果，并发探测时保留较大的下界；期间调用过 Refresh 时丢弃
+func (p *Paginator[T]) storeSizes(lower []int, exact []bool, gen int) {
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	if gen != p.sizeGen || len(p.lower) != len(lower) {
+		return
+	}
+	for i := range lower {
+		p.lower[i] = max(p.lower[i], lower[i])
+		p.exact[i] = p.exact[i] || exact[i]
+	}
+}
+
+func (p *Paginator[T]) resetSizes() {
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	p.lower, p.exact = nil, nil
+	p.sizeGen++
+}
@@ -0,0 +1,207 @@
+package paginator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// unknownSource 不提供总数的切片数据源，记录 GetChunk 调用次数
+type unknownSource struct {
+	SliceSource[int]
+	chunks int
+}
+
+func (s *unknownSource) GetChunk(offset, limit int) ([]int, error) {
+	s.chunks++
+	return s.SliceSource.GetChunk(offset, limit)
+}
+
+func (s *unknownSource) Total() int {
+	return UnknownTotal
+}
+
+func seq(from, to int) []int {
+	var s []int
+	for i := from; i <= to; i++ {
+		s = append(s, i)
+	}
+	return s
+}
+
+func TestPaginator_UnknownTotal(t *testing.T) {
+	newP := func() *Paginator[int] {
+		return NewPaginator([]DataSource[int]{
+			NewSliceSource([]int{1, 2}),
+			&unknownSource{SliceSource: SliceSource[int]{data: seq(3, 9)}},
+			NewSliceSource([]int{10, 11, 12}),
+		}, 3)
+	}
+
+	tests := []struct {
+		page int
+		want []int
+		info PageInfo
+	}{
+		{0, []int{1, 2, 3}, PageInfo{Page: 0, PageSize: 3, TotalItems: 8, TotalPages: 3, HasNext: true, Estimated: true}},
+		{1, []int{4, 5, 6}, PageInfo{Page: 1, PageSize: 3, TotalItems: 11, TotalPages: 4, HasNext: true, HasPrev: true, Estimated: true}},
+		{2, []int{7, 8, 9}, PageInfo{Page: 2, PageSize: 3, TotalItems: 12, TotalPages: 4, HasNext: true, HasPrev: true}},
+		{3, []int{10, 11, 12}, PageInfo{Page: 3, PageSize: 3, TotalItems: 12, TotalPages: 4, HasPrev: true}},
+		{4, []int{}, PageInfo{Page: 4, PageSize: 3, TotalItems: 12, TotalPages: 4, HasPrev: true}},
+	}
+	p := newP()
+	for _, tt := range tests {
+		got, info, err := p.GetPageInfo(tt.page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GetPage(%d) = %v, want %v", tt.page, got, tt.want)
+		}
+		if info != tt.info {
+			t.Errorf("GetPage(%d) info = %+v, want %+v", tt.page, info, tt.info)
+		}
+	}
+
+	t.Run("jump past unknown source", func(t *testing.T) {
+		got, info, err := newP().GetPageInfo(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, []int{10, 11, 12}) || info.Estimated || info.HasNext {
+			t.Errorf("GetPage(3) = %v, %+v", got, info)
+		}
+	})
+
+	t.Run("size remembered", func(t *testing.T) {
+		src := &unknownSource{SliceSource: SliceSource[int]{data: seq(1, 5)}}
+		p := NewPaginator([]DataSource[int]{src}, 2)
+		if _, err := p.Total(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		probes := src.chunks
+		if _, err := p.GetPage(1); err != nil {
+			t.Fatal(err)
+		}
+		if got := src.chunks - probes; got != 1 {
+			t.Errorf("GetChunk calls after discovery = %d, want 1", got)
+		}
+
+		src.data = seq(1, 7)
+		p.Refresh()
+		if total, _ := p.Total(context.Background()); total != 7 {
+			t.Errorf("Total after Refresh = %d, want 7", total)
+		}
+	})
+
+	t.Run("range walk total", func(t *testing.T) {
+		p := newP()
+		got, err := p.GetRange(1, 9)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, seq(2, 10)) {
+			t.Errorf("GetRange = %v", got)
+		}
+
+		var walked []int
+		err = newP().Walk(context.Background(), 5, func(chunk []int) error {
+			walked = append(walked, chunk...)
+			return nil
+		})
+		if err != nil || !reflect.DeepEqual(walked, seq(1, 12)) {
+			t.Errorf("Walk = %v, %v", walked, err)
+		}
+
+		if total, err := newP().Total(context.Background()); err != nil || total != 12 {
+			t.Errorf("Total = %d, %v", total, err)
+		}
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		p := NewPaginator([]DataSource[int]{
+			&unknownSource{SliceSource: SliceSource[int]{data: seq(1, 4)}},
+		}, 3, WithReverse[int]())
+		got, info, err := p.GetPageInfo(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, []int{4, 3, 2}) || info.TotalItems != 4 || info.Estimated {
+			t.Errorf("GetPage(0) = %v, %+v", got, info)
+		}
+	})
+	t.Run("partial probe failure", func(t *testing.T) {
+		errDown := errors.New("down")
+		p := NewPaginatorCtx([]DataSourceCtx[int]{
+			Named[int]("stream", &ctxSource{total: UnknownTotal, chunkErr: errDown}),
+			FromDataSource[int](NewSliceSource(seq(1, 4))),
+		}, 3, WithPartialResults[int]())
+
+		got, info, err := p.GetPageInfo(0)
+		var srcErrs SourceErrors
+		if !errors.As(err, &srcErrs) || !errors.Is(srcErrs["stream"], errDown) {
+			t.Fatalf("GetPageInfo() error = %v, want SourceErrors for stream", err)
+		}
+		if !reflect.DeepEqual(got, []int{1, 2, 3}) || info.TotalItems != 4 || info.Estimated {
+			t.Errorf("GetPage(0) = %v, %+v", got, info)
+		}
+		if got, err := p.GetRange(2, 5); !reflect.DeepEqual(got, []int{3, 4}) || !errors.Is(err, errDown) {
+			t.Errorf("GetRange() = %v, %v", got, err)
+		}
+		if total, err := p.Total(context.Background()); total != 4 || !errors.Is(err, errDown) {
+			t.Errorf("Total() = %v, %v", total, err)
+		}
+	})
+
+	t.Run("probe does not block other pages", func(t *testing.T) {
+		src := &gatedSource{entered: make(chan struct{}, 1), release: make(chan struct{})}
+		p := NewPaginatorCtx([]DataSourceCtx[int]{FromDataSource[int](NewSliceSource(seq(1, 4))), src}, 2)
+
+		probing := make(chan error, 1)
+		go func() {
+			_, err := p.GetPage(3)
+			probing <- err
+		}()
+		<-src.entered
+
+		// 第0页不需要探测，不应等待进行中的探测
+		done := make(chan []int, 1)
+		go func() {
+			page, _ := p.GetPage(0)
+			done <- page
+		}()
+		select {
+		case page := <-done:
+			if !reflect.DeepEqual(page, []int{1, 2}) {
+				t.Errorf("GetPage(0) = %v", page)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("GetPage(0) blocked behind a slow probe")
+		}
+		close(src.release)
+		if err := <-probing; err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// gatedSource 未知总数的空数据源，GetChunk 阻塞到 release 关闭
+type gatedSource struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *gatedSource) GetChunk(ctx context.Context, offset, limit int) ([]int, error) {
+	select {
+	case s.entered <- struct{}{}:
+	default:
+	}
+	<-s.release
+	return []int{}, nil
+}
+
+func (s *gatedSource) Total(ctx context.Context) (int, error) {
+	return UnknownTotal, nil
+}
@@ -0,0 +1,75 @@
+package result
+
+import "errors"
+
+// Result 值或错误，零值为 Ok(零值)；用于在批量操作中保留每个元素各自的成败
+type Result[T any] struct {
+	v   T
+	err error
+}
+
+func Ok[T any](v T) Result[T] {
+	return Result[T]{v: v}
+}
+
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// Of 包装 (T, error) 形式的返回值，出错时仍保留 v，可由 Unwrap 取回
+func Of[T any](v T, err error) Result[T] {
+	return Result[T]{v: v, err: err}
+}
+
+// Unwrap 拆回 (T, error)
+func (r Result[T]) Unwrap() (T, error) {
+	return r.v, r.err
+}
+
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// OrElse 出错时返回 def
+func (r Result[T]) OrElse(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.v
+}
+
+// Map 出错时原样传递错误，不调用 fn
+func Map[T, R any](r Result[T], fn func(T) R) Result[R] {
+	if r.err != nil {
+		return Err[R](r.err)
+	}
+	return Ok(fn(r.v))
+}
+
+// AndThen 串联可能出错的操作
+func AndThen[T, R any](r Result[T], fn func(T) Result[R]) Result[R] {
+	if r.err != nil {
+		return Err[R](r.err)
+	}
+	return fn(r.v)
+}
+
+// Collect 返回所有成功的值（保持顺序）以及 errors.Join 合并后的错误，全部成功时错误为 nil
+func Collect[T any](rs []Result[T]) ([]T, error) {
+	var (
+		vals = make([]T, 0, len(rs))
+		errs []error
+	)
+	for _, r := range rs {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		vals = append(vals, r.v)
+	}
+	return vals, errors.Join(errs...)
+}
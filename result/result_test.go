@@ -0,0 +1,97 @@
+package result
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var errBad = errors.New("bad")
+
+func TestResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       Result[int]
+		wantV   int
+		wantErr error
+		orElse  int
+	}{
+		{"ok", Ok(1), 1, nil, 1},
+		{"err", Err[int](errBad), 0, errBad, -1},
+		{"of ok", Of(2, nil), 2, nil, 2},
+		{"of err keeps value", Of(3, errBad), 3, errBad, -1},
+		{"zero value", Result[int]{}, 0, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.r.Unwrap()
+			if v != tt.wantV || err != tt.wantErr {
+				t.Errorf("Unwrap() = %v, %v, want %v, %v", v, err, tt.wantV, tt.wantErr)
+			}
+			if tt.r.IsOk() != (tt.wantErr == nil) || tt.r.Err() != tt.wantErr {
+				t.Errorf("IsOk() = %v, Err() = %v", tt.r.IsOk(), tt.r.Err())
+			}
+			if got := tt.r.OrElse(-1); got != tt.orElse {
+				t.Errorf("OrElse() = %v, want %v", got, tt.orElse)
+			}
+		})
+	}
+}
+
+func TestMapAndThen(t *testing.T) {
+	atoi := func(s string) Result[int] { return Of(strconv.Atoi(s)) }
+	double := func(n int) int { return n * 2 }
+
+	tests := []struct {
+		in      Result[string]
+		want    int
+		wantErr bool
+	}{
+		{Ok("21"), 42, false},
+		{Ok("x"), 0, true},
+		{Err[string](errBad), 0, true},
+	}
+	for _, tt := range tests {
+		got, err := Map(AndThen(tt.in, atoi), double).Unwrap()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%v: got %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	called := false
+	Map(Err[int](errBad), func(int) int { called = true; return 0 })
+	if called {
+		t.Error("Map called fn on Err")
+	}
+}
+
+func TestCollect(t *testing.T) {
+	errOther := errors.New("other")
+	tests := []struct {
+		name     string
+		rs       []Result[int]
+		want     []int
+		wantErrs []error
+	}{
+		{"empty", nil, []int{}, nil},
+		{"all ok", []Result[int]{Ok(1), Ok(2)}, []int{1, 2}, nil},
+		{"partial", []Result[int]{Ok(1), Err[int](errBad), Ok(3), Of(4, errOther)}, []int{1, 3}, []error{errBad, errOther}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Collect(tt.rs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Collect() = %v, want %v", got, tt.want)
+			}
+			if (err != nil) != (len(tt.wantErrs) > 0) {
+				t.Errorf("Collect() err = %v", err)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("Collect() err = %v, want wrapping %v", err, want)
+				}
+			}
+		})
+	}
+}